@@ -0,0 +1,486 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: calculator.proto
+
+package calculatorpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Card is a playing card. Rank runs from 2 to 14 (ace); suit is 0 clubs,
+// 1 diamonds, 2 hearts or 3 spades.
+type Card struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rank          int32                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	Suit          int32                  `protobuf:"varint,2,opt,name=suit,proto3" json:"suit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Card) Reset() {
+	*x = Card{}
+	mi := &file_calculator_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Card) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Card) ProtoMessage() {}
+
+func (x *Card) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Card.ProtoReflect.Descriptor instead.
+func (*Card) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Card) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *Card) GetSuit() int32 {
+	if x != nil {
+		return x.Suit
+	}
+	return 0
+}
+
+type EquityRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PlayerCards    []*Card                `protobuf:"bytes,1,rep,name=player_cards,json=playerCards,proto3" json:"player_cards,omitempty"`
+	OpponentCards  []*Card                `protobuf:"bytes,2,rep,name=opponent_cards,json=opponentCards,proto3" json:"opponent_cards,omitempty"`
+	CommunityCards []*Card                `protobuf:"bytes,3,rep,name=community_cards,json=communityCards,proto3" json:"community_cards,omitempty"`
+	DeadCards      []*Card                `protobuf:"bytes,4,rep,name=dead_cards,json=deadCards,proto3" json:"dead_cards,omitempty"`
+	// Defaults to 10000 and is capped by the server.
+	NumIterations int32 `protobuf:"varint,5,opt,name=num_iterations,json=numIterations,proto3" json:"num_iterations,omitempty"`
+	// Defaults to 8 workers and is capped by the server.
+	NumConcurrent int32 `protobuf:"varint,6,opt,name=num_concurrent,json=numConcurrent,proto3" json:"num_concurrent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EquityRequest) Reset() {
+	*x = EquityRequest{}
+	mi := &file_calculator_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EquityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EquityRequest) ProtoMessage() {}
+
+func (x *EquityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EquityRequest.ProtoReflect.Descriptor instead.
+func (*EquityRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *EquityRequest) GetPlayerCards() []*Card {
+	if x != nil {
+		return x.PlayerCards
+	}
+	return nil
+}
+
+func (x *EquityRequest) GetOpponentCards() []*Card {
+	if x != nil {
+		return x.OpponentCards
+	}
+	return nil
+}
+
+func (x *EquityRequest) GetCommunityCards() []*Card {
+	if x != nil {
+		return x.CommunityCards
+	}
+	return nil
+}
+
+func (x *EquityRequest) GetDeadCards() []*Card {
+	if x != nil {
+		return x.DeadCards
+	}
+	return nil
+}
+
+func (x *EquityRequest) GetNumIterations() int32 {
+	if x != nil {
+		return x.NumIterations
+	}
+	return 0
+}
+
+func (x *EquityRequest) GetNumConcurrent() int32 {
+	if x != nil {
+		return x.NumConcurrent
+	}
+	return 0
+}
+
+type EquityResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	WinProbability  float64                `protobuf:"fixed64,1,opt,name=win_probability,json=winProbability,proto3" json:"win_probability,omitempty"`
+	LoseProbability float64                `protobuf:"fixed64,2,opt,name=lose_probability,json=loseProbability,proto3" json:"lose_probability,omitempty"`
+	TieProbability  float64                `protobuf:"fixed64,3,opt,name=tie_probability,json=tieProbability,proto3" json:"tie_probability,omitempty"`
+	Iterations      int32                  `protobuf:"varint,4,opt,name=iterations,proto3" json:"iterations,omitempty"`
+	PlayerHand      string                 `protobuf:"bytes,5,opt,name=player_hand,json=playerHand,proto3" json:"player_hand,omitempty"`
+	// Total is how many iterations the simulation runs in all.
+	Total         int32 `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
+	Done          bool  `protobuf:"varint,7,opt,name=done,proto3" json:"done,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EquityResponse) Reset() {
+	*x = EquityResponse{}
+	mi := &file_calculator_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EquityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EquityResponse) ProtoMessage() {}
+
+func (x *EquityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EquityResponse.ProtoReflect.Descriptor instead.
+func (*EquityResponse) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *EquityResponse) GetWinProbability() float64 {
+	if x != nil {
+		return x.WinProbability
+	}
+	return 0
+}
+
+func (x *EquityResponse) GetLoseProbability() float64 {
+	if x != nil {
+		return x.LoseProbability
+	}
+	return 0
+}
+
+func (x *EquityResponse) GetTieProbability() float64 {
+	if x != nil {
+		return x.TieProbability
+	}
+	return 0
+}
+
+func (x *EquityResponse) GetIterations() int32 {
+	if x != nil {
+		return x.Iterations
+	}
+	return 0
+}
+
+func (x *EquityResponse) GetPlayerHand() string {
+	if x != nil {
+		return x.PlayerHand
+	}
+	return ""
+}
+
+func (x *EquityResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *EquityResponse) GetDone() bool {
+	if x != nil {
+		return x.Done
+	}
+	return false
+}
+
+type EvaluateHandRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Between 1 and 7 cards, such as two hole cards and the board.
+	Cards         []*Card `protobuf:"bytes,1,rep,name=cards,proto3" json:"cards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateHandRequest) Reset() {
+	*x = EvaluateHandRequest{}
+	mi := &file_calculator_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateHandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateHandRequest) ProtoMessage() {}
+
+func (x *EvaluateHandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateHandRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateHandRequest) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EvaluateHandRequest) GetCards() []*Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+type EvaluateHandResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Category is the hand type, such as "Full House".
+	Category string `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	// Description names the hand, such as "Full House, Kings full of Sevens".
+	Description string  `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	BestHand    []*Card `protobuf:"bytes,3,rep,name=best_hand,json=bestHand,proto3" json:"best_hand,omitempty"`
+	// Score is higher for stronger hands and equal for hands that tie.
+	Score int64 `protobuf:"varint,4,opt,name=score,proto3" json:"score,omitempty"`
+	// Index is the hand's place among the 7462 distinct five card hands, 1
+	// being a royal flush, or 0 for fewer than five cards.
+	Index         int32 `protobuf:"varint,5,opt,name=index,proto3" json:"index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EvaluateHandResponse) Reset() {
+	*x = EvaluateHandResponse{}
+	mi := &file_calculator_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EvaluateHandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateHandResponse) ProtoMessage() {}
+
+func (x *EvaluateHandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_calculator_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateHandResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateHandResponse) Descriptor() ([]byte, []int) {
+	return file_calculator_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EvaluateHandResponse) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *EvaluateHandResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *EvaluateHandResponse) GetBestHand() []*Card {
+	if x != nil {
+		return x.BestHand
+	}
+	return nil
+}
+
+func (x *EvaluateHandResponse) GetScore() int64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *EvaluateHandResponse) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+var File_calculator_proto protoreflect.FileDescriptor
+
+const file_calculator_proto_rawDesc = "" +
+	"\n" +
+	"\x10calculator.proto\x12\rcalculator.v1\".\n" +
+	"\x04Card\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x05R\x04rank\x12\x12\n" +
+	"\x04suit\x18\x02 \x01(\x05R\x04suit\"\xc3\x02\n" +
+	"\rEquityRequest\x126\n" +
+	"\fplayer_cards\x18\x01 \x03(\v2\x13.calculator.v1.CardR\vplayerCards\x12:\n" +
+	"\x0eopponent_cards\x18\x02 \x03(\v2\x13.calculator.v1.CardR\ropponentCards\x12<\n" +
+	"\x0fcommunity_cards\x18\x03 \x03(\v2\x13.calculator.v1.CardR\x0ecommunityCards\x122\n" +
+	"\n" +
+	"dead_cards\x18\x04 \x03(\v2\x13.calculator.v1.CardR\tdeadCards\x12%\n" +
+	"\x0enum_iterations\x18\x05 \x01(\x05R\rnumIterations\x12%\n" +
+	"\x0enum_concurrent\x18\x06 \x01(\x05R\rnumConcurrent\"\xf8\x01\n" +
+	"\x0eEquityResponse\x12'\n" +
+	"\x0fwin_probability\x18\x01 \x01(\x01R\x0ewinProbability\x12)\n" +
+	"\x10lose_probability\x18\x02 \x01(\x01R\x0floseProbability\x12'\n" +
+	"\x0ftie_probability\x18\x03 \x01(\x01R\x0etieProbability\x12\x1e\n" +
+	"\n" +
+	"iterations\x18\x04 \x01(\x05R\n" +
+	"iterations\x12\x1f\n" +
+	"\vplayer_hand\x18\x05 \x01(\tR\n" +
+	"playerHand\x12\x14\n" +
+	"\x05total\x18\x06 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04done\x18\a \x01(\bR\x04done\"@\n" +
+	"\x13EvaluateHandRequest\x12)\n" +
+	"\x05cards\x18\x01 \x03(\v2\x13.calculator.v1.CardR\x05cards\"\xb2\x01\n" +
+	"\x14EvaluateHandResponse\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x120\n" +
+	"\tbest_hand\x18\x03 \x03(\v2\x13.calculator.v1.CardR\bbestHand\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x03R\x05score\x12\x14\n" +
+	"\x05index\x18\x05 \x01(\x05R\x05index2\x84\x02\n" +
+	"\n" +
+	"Calculator\x12N\n" +
+	"\x0fCalculateEquity\x12\x1c.calculator.v1.EquityRequest\x1a\x1d.calculator.v1.EquityResponse\x12M\n" +
+	"\fStreamEquity\x12\x1c.calculator.v1.EquityRequest\x1a\x1d.calculator.v1.EquityResponse0\x01\x12W\n" +
+	"\fEvaluateHand\x12\".calculator.v1.EvaluateHandRequest\x1a#.calculator.v1.EvaluateHandResponseBJZHgithub.com/Palaszontko/texas-holdem-hand-calculator/backend/calculatorpbb\x06proto3"
+
+var (
+	file_calculator_proto_rawDescOnce sync.Once
+	file_calculator_proto_rawDescData []byte
+)
+
+func file_calculator_proto_rawDescGZIP() []byte {
+	file_calculator_proto_rawDescOnce.Do(func() {
+		file_calculator_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_calculator_proto_rawDesc), len(file_calculator_proto_rawDesc)))
+	})
+	return file_calculator_proto_rawDescData
+}
+
+var file_calculator_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_calculator_proto_goTypes = []any{
+	(*Card)(nil),                 // 0: calculator.v1.Card
+	(*EquityRequest)(nil),        // 1: calculator.v1.EquityRequest
+	(*EquityResponse)(nil),       // 2: calculator.v1.EquityResponse
+	(*EvaluateHandRequest)(nil),  // 3: calculator.v1.EvaluateHandRequest
+	(*EvaluateHandResponse)(nil), // 4: calculator.v1.EvaluateHandResponse
+}
+var file_calculator_proto_depIdxs = []int32{
+	0, // 0: calculator.v1.EquityRequest.player_cards:type_name -> calculator.v1.Card
+	0, // 1: calculator.v1.EquityRequest.opponent_cards:type_name -> calculator.v1.Card
+	0, // 2: calculator.v1.EquityRequest.community_cards:type_name -> calculator.v1.Card
+	0, // 3: calculator.v1.EquityRequest.dead_cards:type_name -> calculator.v1.Card
+	0, // 4: calculator.v1.EvaluateHandRequest.cards:type_name -> calculator.v1.Card
+	0, // 5: calculator.v1.EvaluateHandResponse.best_hand:type_name -> calculator.v1.Card
+	1, // 6: calculator.v1.Calculator.CalculateEquity:input_type -> calculator.v1.EquityRequest
+	1, // 7: calculator.v1.Calculator.StreamEquity:input_type -> calculator.v1.EquityRequest
+	3, // 8: calculator.v1.Calculator.EvaluateHand:input_type -> calculator.v1.EvaluateHandRequest
+	2, // 9: calculator.v1.Calculator.CalculateEquity:output_type -> calculator.v1.EquityResponse
+	2, // 10: calculator.v1.Calculator.StreamEquity:output_type -> calculator.v1.EquityResponse
+	4, // 11: calculator.v1.Calculator.EvaluateHand:output_type -> calculator.v1.EvaluateHandResponse
+	9, // [9:12] is the sub-list for method output_type
+	6, // [6:9] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_calculator_proto_init() }
+func file_calculator_proto_init() {
+	if File_calculator_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_calculator_proto_rawDesc), len(file_calculator_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_calculator_proto_goTypes,
+		DependencyIndexes: file_calculator_proto_depIdxs,
+		MessageInfos:      file_calculator_proto_msgTypes,
+	}.Build()
+	File_calculator_proto = out.File
+	file_calculator_proto_goTypes = nil
+	file_calculator_proto_depIdxs = nil
+}
@@ -0,0 +1,217 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: calculator.proto
+
+package calculatorpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Calculator_CalculateEquity_FullMethodName = "/calculator.v1.Calculator/CalculateEquity"
+	Calculator_StreamEquity_FullMethodName    = "/calculator.v1.Calculator/StreamEquity"
+	Calculator_EvaluateHand_FullMethodName    = "/calculator.v1.Calculator/EvaluateHand"
+)
+
+// CalculatorClient is the client API for Calculator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Calculator offers the HTTP API's equity calculations and hand evaluation
+// over gRPC.
+type CalculatorClient interface {
+	// CalculateEquity estimates the player's equity against one opponent.
+	CalculateEquity(ctx context.Context, in *EquityRequest, opts ...grpc.CallOption) (*EquityResponse, error)
+	// StreamEquity runs the same simulation but sends the running result as it
+	// goes. The last message has done set. Streams may run more iterations than
+	// CalculateEquity allows.
+	StreamEquity(ctx context.Context, in *EquityRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EquityResponse], error)
+	// EvaluateHand names the best five card hand among the given cards.
+	EvaluateHand(ctx context.Context, in *EvaluateHandRequest, opts ...grpc.CallOption) (*EvaluateHandResponse, error)
+}
+
+type calculatorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCalculatorClient(cc grpc.ClientConnInterface) CalculatorClient {
+	return &calculatorClient{cc}
+}
+
+func (c *calculatorClient) CalculateEquity(ctx context.Context, in *EquityRequest, opts ...grpc.CallOption) (*EquityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EquityResponse)
+	err := c.cc.Invoke(ctx, Calculator_CalculateEquity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calculatorClient) StreamEquity(ctx context.Context, in *EquityRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[EquityResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Calculator_ServiceDesc.Streams[0], Calculator_StreamEquity_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[EquityRequest, EquityResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Calculator_StreamEquityClient = grpc.ServerStreamingClient[EquityResponse]
+
+func (c *calculatorClient) EvaluateHand(ctx context.Context, in *EvaluateHandRequest, opts ...grpc.CallOption) (*EvaluateHandResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvaluateHandResponse)
+	err := c.cc.Invoke(ctx, Calculator_EvaluateHand_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CalculatorServer is the server API for Calculator service.
+// All implementations must embed UnimplementedCalculatorServer
+// for forward compatibility.
+//
+// Calculator offers the HTTP API's equity calculations and hand evaluation
+// over gRPC.
+type CalculatorServer interface {
+	// CalculateEquity estimates the player's equity against one opponent.
+	CalculateEquity(context.Context, *EquityRequest) (*EquityResponse, error)
+	// StreamEquity runs the same simulation but sends the running result as it
+	// goes. The last message has done set. Streams may run more iterations than
+	// CalculateEquity allows.
+	StreamEquity(*EquityRequest, grpc.ServerStreamingServer[EquityResponse]) error
+	// EvaluateHand names the best five card hand among the given cards.
+	EvaluateHand(context.Context, *EvaluateHandRequest) (*EvaluateHandResponse, error)
+	mustEmbedUnimplementedCalculatorServer()
+}
+
+// UnimplementedCalculatorServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCalculatorServer struct{}
+
+func (UnimplementedCalculatorServer) CalculateEquity(context.Context, *EquityRequest) (*EquityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CalculateEquity not implemented")
+}
+func (UnimplementedCalculatorServer) StreamEquity(*EquityRequest, grpc.ServerStreamingServer[EquityResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamEquity not implemented")
+}
+func (UnimplementedCalculatorServer) EvaluateHand(context.Context, *EvaluateHandRequest) (*EvaluateHandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EvaluateHand not implemented")
+}
+func (UnimplementedCalculatorServer) mustEmbedUnimplementedCalculatorServer() {}
+func (UnimplementedCalculatorServer) testEmbeddedByValue()                    {}
+
+// UnsafeCalculatorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CalculatorServer will
+// result in compilation errors.
+type UnsafeCalculatorServer interface {
+	mustEmbedUnimplementedCalculatorServer()
+}
+
+func RegisterCalculatorServer(s grpc.ServiceRegistrar, srv CalculatorServer) {
+	// If the following call pancis, it indicates UnimplementedCalculatorServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Calculator_ServiceDesc, srv)
+}
+
+func _Calculator_CalculateEquity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EquityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServer).CalculateEquity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calculator_CalculateEquity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServer).CalculateEquity(ctx, req.(*EquityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calculator_StreamEquity_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EquityRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalculatorServer).StreamEquity(m, &grpc.GenericServerStream[EquityRequest, EquityResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Calculator_StreamEquityServer = grpc.ServerStreamingServer[EquityResponse]
+
+func _Calculator_EvaluateHand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateHandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalculatorServer).EvaluateHand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calculator_EvaluateHand_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalculatorServer).EvaluateHand(ctx, req.(*EvaluateHandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Calculator_ServiceDesc is the grpc.ServiceDesc for Calculator service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Calculator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "calculator.v1.Calculator",
+	HandlerType: (*CalculatorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CalculateEquity",
+			Handler:    _Calculator_CalculateEquity_Handler,
+		},
+		{
+			MethodName: "EvaluateHand",
+			Handler:    _Calculator_EvaluateHand_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamEquity",
+			Handler:       _Calculator_StreamEquity_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "calculator.proto",
+}
@@ -0,0 +1,5 @@
+// Package calculatorpb holds the protocol buffer messages and gRPC stubs of
+// the Calculator service, generated from calculator.proto.
+package calculatorpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative calculator.proto
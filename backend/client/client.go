@@ -0,0 +1,213 @@
+// Package client is a typed Go client for the calculator's HTTP API, as
+// described by the OpenAPI document served at /api/openapi.json.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	// BaseURL is where the server is, such as "http://localhost:8080".
+	BaseURL string
+	// APIKey, when set, is sent in the X-API-Key header.
+	APIKey string
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+type Client struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+func NewClient(config Config) *Client {
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{
+		baseURL:    strings.TrimRight(config.BaseURL, "/"),
+		apiKey:     config.APIKey,
+		httpClient: httpClient,
+	}
+}
+
+// Error is a response the server answered with an error status. Its
+// message is the plain-text body.
+type Error struct {
+	StatusCode int
+	Message    string
+	// RetryAfter is how long the server asked to wait, for 429 and 503.
+	RetryAfter time.Duration
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Simulate estimates the player's equity against one opponent.
+func (c *Client) Simulate(ctx context.Context, req SimulationRequest) (*SimulationResponse, error) {
+	var resp SimulationResponse
+	if err := c.do(ctx, http.MethodPost, "/api/simulation", req, &resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SimulateBatch runs up to 500 scenarios. Results come back in order, each
+// holding either a result or an error.
+func (c *Client) SimulateBatch(ctx context.Context, scenarios []SimulationRequest) ([]BatchSimulationResult, error) {
+	var resp BatchSimulationResponse
+	if err := c.do(ctx, http.MethodPost, "/api/simulation/batch", BatchSimulationRequest{Scenarios: scenarios}, &resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
+
+// CreateJob queues a simulation too large for one request.
+func (c *Client) CreateJob(ctx context.Context, req SimulationRequest) (*Job, error) {
+	var job Job
+	if err := c.do(ctx, http.MethodPost, "/api/jobs", req, &job, http.StatusAccepted); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (c *Client) GetJob(ctx context.Context, id string) (*Job, error) {
+	var job Job
+	if err := c.do(ctx, http.MethodGet, "/api/jobs/"+url.PathEscape(id), nil, &job, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// CancelJob stops a job. The job keeps the partial result it had reached.
+func (c *Client) CancelJob(ctx context.Context, id string) (*Job, error) {
+	var job Job
+	if err := c.do(ctx, http.MethodDelete, "/api/jobs/"+url.PathEscape(id), nil, &job, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// WaitJob polls the job every interval until it has finished or ctx is
+// done.
+func (c *Client) WaitJob(ctx context.Context, id string, interval time.Duration) (*Job, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		job, err := c.GetJob(ctx, id)
+		if err != nil || job.Finished() {
+			return job, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Client) Health(ctx context.Context) (*HealthResponse, error) {
+	var resp HealthResponse
+	if err := c.do(ctx, http.MethodGet, "/api/health", nil, &resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Ready returns the server's capacity. A server that is shutting down
+// answers with status "draining" rather than an error.
+func (c *Client) Ready(ctx context.Context) (*ReadinessResponse, error) {
+	var resp ReadinessResponse
+	if err := c.do(ctx, http.MethodGet, "/api/health/ready", nil, &resp, http.StatusOK, http.StatusServiceUnavailable); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Stats returns the HUD statistics of one player.
+func (c *Client) Stats(ctx context.Context, player string) (*PlayerStats, error) {
+	var resp []PlayerStats
+	if err := c.do(ctx, http.MethodGet, "/api/stats?player="+url.QueryEscape(player), nil, &resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	if len(resp) == 0 {
+		return nil, &Error{StatusCode: http.StatusNotFound, Message: "Player not found"}
+	}
+	return &resp[0], nil
+}
+
+func (c *Client) Replay(ctx context.Context, req ReplayRequest) (*ReplayResponse, error) {
+	var resp ReplayResponse
+	if err := c.do(ctx, http.MethodPost, "/api/replay", req, &resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Usage lists the usage of every API key. It needs an admin key.
+func (c *Client) Usage(ctx context.Context) ([]Usage, error) {
+	var resp UsageResponse
+	if err := c.do(ctx, http.MethodGet, "/api/admin/usage", nil, &resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return resp.Keys, nil
+}
+
+// do sends body as JSON, if there is one, and decodes the response into
+// out when its status is one of want.
+func (c *Client) do(ctx context.Context, method, path string, body, out any, want ...int) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("X-API-Key", c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	for _, status := range want {
+		if resp.StatusCode == status {
+			return json.NewDecoder(resp.Body).Decode(out)
+		}
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+	apiErr := &Error{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(message))}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return apiErr
+}
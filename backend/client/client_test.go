@@ -0,0 +1,106 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/api"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/api/handlers"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/apikeys"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/jobs"
+)
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	keys, err := apikeys.NewStore([]apikeys.Key{
+		{Name: "partner", Key: "partner-key", IterationsPerDay: 5_000},
+		{Name: "ops", Key: "ops-key", Admin: true},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager := jobs.NewManager(jobs.Config{Workers: 1, QueueSize: 2, TTL: time.Minute})
+
+	server := httptest.NewServer(api.NewRouter(api.Options{
+		Handlers:       handlers.Config{MaxIterations: 2_000, MaxConcurrent: 2, MaxJobIterations: 4_000, Keys: keys},
+		MaxBodyBytes:   1 << 20,
+		Jobs:           manager,
+		AllowAnonymous: true,
+	}))
+
+	t.Cleanup(func() {
+		server.Close()
+		manager.Close()
+		keys.Close()
+	})
+	return server
+}
+
+func TestClient(t *testing.T) {
+	server := newServer(t)
+	ctx := context.Background()
+	partner := NewClient(Config{BaseURL: server.URL + "/", APIKey: "partner-key"})
+
+	aces := []Card{{Rank: Ace, Suit: Clubs}, {Rank: Ace, Suit: Diamonds}}
+
+	resp, err := partner.Simulate(ctx, SimulationRequest{PlayerCards: aces, NumIterations: 1_000})
+	if err != nil {
+		t.Fatalf("Simulate() error = %v", err)
+	}
+	if resp.Iterations != 1_000 || resp.WinProbability < 0.7 || resp.PlayerHand == "" {
+		t.Errorf("Simulate() = %+v", resp)
+	}
+
+	results, err := partner.SimulateBatch(ctx, []SimulationRequest{
+		{PlayerCards: aces, NumIterations: 100},
+		{PlayerCards: aces[:1]},
+	})
+	if err != nil || len(results) != 2 || results[0].Result == nil || results[1].Error == "" {
+		t.Errorf("SimulateBatch() = %+v, %v", results, err)
+	}
+
+	job, err := partner.CreateJob(ctx, SimulationRequest{PlayerCards: aces, NumIterations: 2_000, NumConcurrent: 2})
+	if err != nil {
+		t.Fatalf("CreateJob() error = %v", err)
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	job, err = partner.WaitJob(waitCtx, job.ID, 10*time.Millisecond)
+	if err != nil || job.Status != JobDone || job.Result == nil || job.Result.Iterations != 2_000 {
+		t.Errorf("WaitJob() = %+v, %v", job, err)
+	}
+
+	_, err = partner.Simulate(ctx, SimulationRequest{PlayerCards: aces, NumIterations: 2_000})
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests || apiErr.RetryAfter <= 0 {
+		t.Errorf("Simulate() over quota error = %v", err)
+	}
+
+	_, err = partner.Simulate(ctx, SimulationRequest{PlayerCards: aces[:1]})
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || apiErr.Message == "" {
+		t.Errorf("Simulate() with one card error = %v", err)
+	}
+
+	if _, err := partner.Usage(ctx); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("Usage() as partner error = %v", err)
+	}
+
+	usage, err := NewClient(Config{BaseURL: server.URL, APIKey: "ops-key"}).Usage(ctx)
+	if err != nil || len(usage) != 2 || usage[1].Name != "partner" || usage[1].Iterations != 3_100 {
+		t.Errorf("Usage() = %+v, %v", usage, err)
+	}
+
+	anonymous := NewClient(Config{BaseURL: server.URL})
+	ready, err := anonymous.Ready(ctx)
+	if err != nil || ready.Status != "ready" || ready.Jobs == nil {
+		t.Errorf("Ready() = %+v, %v", ready, err)
+	}
+	if _, err := anonymous.GetJob(ctx, "missing"); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("GetJob(missing) error = %v", err)
+	}
+}
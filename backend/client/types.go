@@ -0,0 +1,212 @@
+package client
+
+import "time"
+
+// Card is a playing card. Rank runs from 2 to 14 (ace); Suit is 0 clubs,
+// 1 diamonds, 2 hearts or 3 spades.
+type Card struct {
+	Rank int
+	Suit int
+}
+
+const (
+	Clubs = iota
+	Diamonds
+	Hearts
+	Spades
+)
+
+const (
+	Jack  = 11
+	Queen = 12
+	King  = 13
+	Ace   = 14
+)
+
+type SimulationRequest struct {
+	PlayerCards    []Card `json:"playerCards"`
+	OpponentCards  []Card `json:"opponentCards,omitempty"`
+	CommunityCards []Card `json:"communityCards,omitempty"`
+	DeadCards      []Card `json:"deadCards,omitempty"`
+	NumIterations  int    `json:"numIterations"`
+	NumConcurrent  int    `json:"numConcurrent"`
+}
+
+type SimulationResponse struct {
+	WinProbability  float64 `json:"winProbability"`
+	LoseProbability float64 `json:"loseProbability"`
+	TieProbability  float64 `json:"tieProbability"`
+	Iterations      int     `json:"iterations"`
+	PlayerHand      string  `json:"playerHand"`
+}
+
+type BatchSimulationRequest struct {
+	Scenarios []SimulationRequest `json:"scenarios"`
+}
+
+// BatchSimulationResult holds either the result of one scenario or the
+// reason it could not be run.
+type BatchSimulationResult struct {
+	Result *SimulationResponse `json:"result,omitempty"`
+	Error  string              `json:"error,omitempty"`
+}
+
+type BatchSimulationResponse struct {
+	Results []BatchSimulationResult `json:"results"`
+}
+
+type JobStatus string
+
+const (
+	JobQueued   JobStatus = "queued"
+	JobRunning  JobStatus = "running"
+	JobDone     JobStatus = "done"
+	JobFailed   JobStatus = "failed"
+	JobCanceled JobStatus = "canceled"
+)
+
+type Job struct {
+	ID         string              `json:"id"`
+	Status     JobStatus           `json:"status"`
+	Completed  int                 `json:"completed"`
+	Total      int                 `json:"total"`
+	Progress   float64             `json:"progress"`
+	Result     *SimulationResponse `json:"result,omitempty"`
+	Error      string              `json:"error,omitempty"`
+	CreatedAt  time.Time           `json:"createdAt"`
+	FinishedAt *time.Time          `json:"finishedAt,omitempty"`
+}
+
+// Finished reports whether the job will not change any more.
+func (j Job) Finished() bool {
+	return j.Status == JobDone || j.Status == JobFailed || j.Status == JobCanceled
+}
+
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+type SimulatorCapacity struct {
+	ActiveWorkers int `json:"activeWorkers"`
+	WorkersInUse  int `json:"workersInUse"`
+	MaxWorkers    int `json:"maxWorkers,omitempty"`
+	MaxConcurrent int `json:"maxConcurrent"`
+	MaxIterations int `json:"maxIterations"`
+}
+
+type JobStats struct {
+	Queued    int  `json:"queued"`
+	QueueSize int  `json:"queueSize"`
+	Running   int  `json:"running"`
+	Workers   int  `json:"workers"`
+	Closed    bool `json:"closed"`
+}
+
+type ReadinessResponse struct {
+	Status    string            `json:"status"`
+	Simulator SimulatorCapacity `json:"simulator"`
+	Jobs      *JobStats         `json:"jobs,omitempty"`
+}
+
+type Ratio struct {
+	Count         int     `json:"count"`
+	Opportunities int     `json:"opportunities"`
+	Percent       float64 `json:"percent"`
+}
+
+type PlayerStats struct {
+	Player            string  `json:"player"`
+	Hands             int     `json:"hands"`
+	VPIP              Ratio   `json:"vpip"`
+	PFR               Ratio   `json:"pfr"`
+	ThreeBet          Ratio   `json:"threeBet"`
+	FoldToThreeBet    Ratio   `json:"foldToThreeBet"`
+	CBet              Ratio   `json:"cBet"`
+	AggressionFactor  float64 `json:"aggressionFactor"`
+	AggressiveActions int     `json:"aggressiveActions"`
+	PassiveActions    int     `json:"passiveActions"`
+	WTSD              Ratio   `json:"wtsd"`
+	WSD               Ratio   `json:"wsd"`
+}
+
+// Action is one line of a hand. Street is 0 preflop to 4 showdown; see the
+// OpenAPI document for the values of Type.
+type Action struct {
+	Street int     `json:"street"`
+	Player string  `json:"player"`
+	Type   int     `json:"type"`
+	Amount float64 `json:"amount,omitempty"`
+	To     float64 `json:"to,omitempty"`
+	AllIn  bool    `json:"allIn,omitempty"`
+	Cards  []Card  `json:"cards,omitempty"`
+}
+
+type Seat struct {
+	Number     int     `json:"number"`
+	Player     string  `json:"player"`
+	Stack      float64 `json:"stack"`
+	SittingOut bool    `json:"sittingOut,omitempty"`
+}
+
+type Hand struct {
+	ID           string             `json:"id"`
+	Site         string             `json:"site,omitempty"`
+	TournamentID string             `json:"tournamentId,omitempty"`
+	Game         string             `json:"game,omitempty"`
+	Currency     string             `json:"currency,omitempty"`
+	SmallBlind   float64            `json:"smallBlind,omitempty"`
+	BigBlind     float64            `json:"bigBlind,omitempty"`
+	Ante         float64            `json:"ante,omitempty"`
+	Time         string             `json:"time,omitempty"`
+	Table        string             `json:"table,omitempty"`
+	MaxPlayers   int                `json:"maxPlayers,omitempty"`
+	ButtonSeat   int                `json:"buttonSeat,omitempty"`
+	Seats        []Seat             `json:"seats,omitempty"`
+	Hero         string             `json:"hero,omitempty"`
+	HoleCards    map[string][]Card  `json:"holeCards,omitempty"`
+	Actions      []Action           `json:"actions,omitempty"`
+	Board        []Card             `json:"board,omitempty"`
+	Winnings     map[string]float64 `json:"winnings,omitempty"`
+	TotalPot     float64            `json:"totalPot,omitempty"`
+	Rake         float64            `json:"rake,omitempty"`
+}
+
+// ReplayRequest takes either the text of a hand history or a parsed hand.
+type ReplayRequest struct {
+	History       string `json:"history,omitempty"`
+	Hand          *Hand  `json:"hand,omitempty"`
+	NumIterations int    `json:"numIterations"`
+}
+
+type Step struct {
+	Street      int                `json:"street"`
+	StreetName  string             `json:"streetName"`
+	Action      *Action            `json:"action,omitempty"`
+	Description string             `json:"description"`
+	Board       []Card             `json:"board"`
+	Pot         float64            `json:"pot"`
+	Stacks      map[string]float64 `json:"stacks"`
+	Folded      []string           `json:"folded"`
+	HoleCards   map[string][]Card  `json:"holeCards"`
+	Equity      map[string]float64 `json:"equity,omitempty"`
+}
+
+type ReplayResponse struct {
+	HandID string `json:"handId"`
+	Steps  []Step `json:"steps"`
+}
+
+type Usage struct {
+	Name             string     `json:"name"`
+	Requests         int64      `json:"requests"`
+	Iterations       int64      `json:"iterations"`
+	Rejected         int64      `json:"rejected"`
+	Day              string     `json:"day,omitempty"`
+	DayIterations    int64      `json:"dayIterations"`
+	IterationsPerDay int64      `json:"iterationsPerDay,omitempty"`
+	LastUsed         *time.Time `json:"lastUsed,omitempty"`
+}
+
+type UsageResponse struct {
+	Keys []Usage `json:"keys"`
+}
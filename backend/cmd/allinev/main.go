@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/ev"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/handhistory"
+)
+
+func main() {
+	player := flag.String("player", "", "player to report on (defaults to the hero of the first hand)")
+	iterations := flag.Int("iterations", 0, "Monte Carlo iterations per all-in, 0 enumerates every board")
+	concurrent := flag.Int("concurrent", runtime.NumCPU(), "number of parallel workers")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] history.txt...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var hands []handhistory.Hand
+	for _, path := range flag.Args() {
+		file, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		parsed, err := handhistory.ParsePokerStars(file)
+		file.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %s: %v\n", path, err)
+		}
+		hands = append(hands, parsed...)
+	}
+
+	if *player == "" && len(hands) > 0 {
+		*player = hands[0].Hero
+	}
+
+	report, err := ev.AllInAdjusted(hands, *player, ev.Options{NumIterations: *iterations, NumConcurrent: *concurrent})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	out := csv.NewWriter(os.Stdout)
+	out.Write([]string{"hand", "all_in", "street", "equity", "actual", "adjusted", "cumulative_actual", "cumulative_adjusted"})
+	for _, point := range report.Points {
+		street, equity := "", ""
+		if point.AllIn {
+			street = point.Street.String()
+			equity = strconv.FormatFloat(point.Equity, 'f', 4, 64)
+		}
+		out.Write([]string{
+			point.HandID,
+			strconv.FormatBool(point.AllIn),
+			street,
+			equity,
+			strconv.FormatFloat(point.Actual, 'f', 2, 64),
+			strconv.FormatFloat(point.Adjusted, 'f', 2, 64),
+			strconv.FormatFloat(point.CumulativeActual, 'f', 2, 64),
+			strconv.FormatFloat(point.CumulativeAdjusted, 'f', 2, 64),
+		})
+	}
+	out.Flush()
+
+	fmt.Fprintf(os.Stderr, "%s: %d hands, %d all-in\n", report.Player, report.Hands, report.AllInHands)
+	fmt.Fprintf(os.Stderr, "Actual winnings:   %.2f\n", report.Actual)
+	fmt.Fprintf(os.Stderr, "All-in adjusted:   %.2f\n", report.Adjusted)
+	fmt.Fprintf(os.Stderr, "Running %s EV by %.2f\n", map[bool]string{true: "above", false: "below"}[report.Actual >= report.Adjusted], report.Actual-report.Adjusted)
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+	simulator "github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/simulation"
+)
+
+func main() {
+	player := flag.String("player", "", "player's hole cards, such as AsKd")
+	opponent := flag.String("opponent", "", "opponent's known hole cards, if any")
+	board := flag.String("board", "", "community cards dealt so far")
+	dead := flag.String("dead", "", "exposed, burned or mucked cards that cannot come")
+	iterations := flag.Int("iterations", 100_000, "number of Monte Carlo iterations")
+	concurrent := flag.Int("concurrent", runtime.NumCPU(), "number of parallel workers")
+	flag.Parse()
+
+	config := simulator.Config{NumIterations: *iterations, NumConcurrent: *concurrent}
+
+	for _, group := range []struct {
+		flag  string
+		value string
+		cards *[]poker.Card
+	}{
+		{"player", *player, &config.PlayerHand.Cards},
+		{"opponent", *opponent, &config.OpponentHand.Cards},
+		{"board", *board, &config.CommunityCards},
+		{"dead", *dead, &config.DeadCards},
+	} {
+		cards, err := parseCards(group.value)
+		if err != nil {
+			fmt.Printf("Error: -%s: %v\n", group.flag, err)
+			os.Exit(2)
+		}
+		*group.cards = cards
+	}
+
+	start := time.Now()
+
+	result, err := simulator.NewSimulator(config).RunSimulation()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Hand: %s\n", config.PlayerHand.EvaluateHandStrenght(config.CommunityCards).Describe())
+	fmt.Printf("Win:  %.2f%%\n", result.WinProbability*100)
+	fmt.Printf("Lose: %.2f%%\n", result.LoseProbability*100)
+	fmt.Printf("Tie:  %.2f%%\n", result.TieProbability*100)
+	fmt.Printf("\nCompleted %d iterations in: %v\n", result.Iterations, time.Since(start).Round(time.Millisecond))
+}
+
+// parseCards reads cards written one after another, such as "AsKd" or
+// "As Kd, 7c".
+func parseCards(value string) ([]poker.Card, error) {
+	codes := strings.Join(strings.FieldsFunc(value, func(r rune) bool { return r == ' ' || r == ',' }), "")
+	if len(codes)%2 != 0 {
+		return nil, fmt.Errorf("invalid cards %q", value)
+	}
+
+	var cards []poker.Card
+	for i := 0; i < len(codes); i += 2 {
+		card, err := poker.ParseCard(codes[i : i+2])
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/bot"
+)
+
+var bots = map[string]func(seed int64) bot.Player{
+	"random":  func(seed int64) bot.Player { return bot.NewRandomBot(seed) },
+	"station": func(int64) bot.Player { return bot.CallingStation{} },
+	"equity":  func(int64) bot.Player { return bot.NewEquityBot(0.6, 200) },
+}
+
+func main() {
+	players := flag.String("players", "equity,station", "comma separated bots to seat: random, station, equity")
+	hands := flag.Int("hands", 100_000, "number of hands to play")
+	concurrent := flag.Int("concurrent", runtime.NumCPU(), "number of parallel workers")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "seed for the deck and the bots")
+	flag.Parse()
+
+	var entrants []bot.Entrant
+	for i, name := range strings.Split(*players, ",") {
+		newPlayer, ok := bots[name]
+		if !ok {
+			fmt.Printf("Error: unknown bot %q\n", name)
+			os.Exit(1)
+		}
+		entrants = append(entrants, bot.Entrant{Name: fmt.Sprintf("%s#%d", name, i+1), New: newPlayer})
+	}
+
+	start := time.Now()
+
+	standings, err := bot.Run(bot.RunConfig{
+		Entrants:      entrants,
+		NumHands:      *hands,
+		SmallBlind:    1,
+		BigBlind:      2,
+		NumConcurrent: *concurrent,
+		Seed:          *seed,
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("%-12s %10s %12s %10s\n", "Bot", "Hands", "bb/100", "±95%")
+	for _, standing := range standings {
+		fmt.Printf("%-12s %10d %12.2f %10.2f\n", standing.Name, standing.Hands, standing.BBPer100, standing.CI95)
+	}
+	fmt.Printf("\nCompleted in: %v\n", time.Since(start).Round(time.Millisecond))
+}
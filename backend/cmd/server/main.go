@@ -1,18 +1,202 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/api"
 	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/api/handlers"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/apikeys"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/config"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/grpcapi"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/handhistory"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/jobs"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/logging"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/ratelimit"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/web"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
 func main() {
-	http.HandleFunc("/api/health", handlers.HealthCheckHandler)
-	http.HandleFunc("/api/simulation", handlers.SimulationHander)
-
-	fmt.Println("Starting server on port 8080")
+	cfg, err := config.Load(os.Args[1:], os.Getenv)
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Printf("Usage of %s:\n%s", os.Args[0], config.Usage())
+		return
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(2)
+	}
 
-	if err := http.ListenAndServe(":8080", nil); err != nil {
+	logger, err := logging.New(os.Stdout, logging.Options{Level: cfg.LogLevel, RedactCards: cfg.RedactCards})
+	if err != nil {
 		fmt.Printf("Error: %v\n", err)
+		os.Exit(2)
+	}
+	slog.SetDefault(logger)
+
+	var hands []handhistory.Hand
+	if cfg.HandHistoryDir != "" {
+		hands, err = handhistory.LoadDir(cfg.HandHistoryDir)
+		if err != nil {
+			slog.Warn("some hand histories could not be loaded", "error", err)
+		}
+		slog.Info("loaded hand histories", "hands", len(hands), "dir", cfg.HandHistoryDir)
+	}
+
+	var keys *apikeys.Store
+	if cfg.APIKeysFile != "" {
+		list, err := apikeys.LoadKeys(cfg.APIKeysFile)
+		if err == nil {
+			keys, err = apikeys.NewStore(list, cfg.UsageFile)
+		}
+		if err != nil {
+			slog.Error("loading API keys failed", "error", err)
+			os.Exit(2)
+		}
+		slog.Info("loaded API keys", "keys", len(list), "usageFile", cfg.UsageFile, "allowAnonymous", cfg.AllowAnonymous)
+	}
+
+	frontend := web.Embedded()
+	if cfg.FrontendDir != "" {
+		frontend = os.DirFS(cfg.FrontendDir)
+		if err := web.Check(frontend); err != nil {
+			slog.Error("loading the frontend failed", "dir", cfg.FrontendDir, "error", err)
+			os.Exit(2)
+		}
+	}
+	if frontend != nil {
+		slog.Info("serving the frontend", "embedded", cfg.FrontendDir == "", "dir", cfg.FrontendDir)
 	}
+
+	jobManager := jobs.NewManager(jobs.Config{Workers: cfg.JobWorkers, QueueSize: cfg.JobQueueSize, TTL: cfg.JobTTL})
+	probe := handlers.NewProbe()
+	workers := ratelimit.NewWorkerPool(cfg.MaxWorkers)
+	limiter := ratelimit.NewLimiter(cfg.RateLimit, cfg.RateBurst)
+
+	router := api.NewRouter(api.Options{
+		Handlers: handlers.Config{
+			MaxIterations:    cfg.MaxIterations,
+			MaxConcurrent:    cfg.MaxConcurrent,
+			MaxJobIterations: cfg.MaxJobIterations,
+			Workers:          workers,
+			Keys:             keys,
+		},
+		AllowedOrigins: cfg.AllowedOrigins,
+		MaxBodyBytes:   cfg.MaxBodyBytes,
+		Hands:          hands,
+		Jobs:           jobManager,
+		Probe:          probe,
+		RateLimiter:    limiter,
+		TrustProxy:     cfg.TrustProxy,
+		AllowAnonymous: cfg.AllowAnonymous,
+		Frontend:       frontend,
+	})
+
+	// Requests run under this context so that the ones still going when the
+	// shutdown deadline passes can be canceled.
+	requests, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+
+	server := &http.Server{
+		Addr:         cfg.ListenAddr,
+		Handler:      router,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+		BaseContext:  func(net.Listener) context.Context { return requests },
+	}
+
+	signals, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	slog.Info("starting server", "addr", cfg.ListenAddr, "logLevel", cfg.LogLevel, "redactCards", cfg.RedactCards)
+
+	errs := make(chan error, 2)
+	go func() {
+		errs <- server.ListenAndServe()
+	}()
+
+	// The gRPC API shares the worker pool, API keys and rate limits with the
+	// HTTP API, so a client cannot get around them by switching protocols.
+	var grpcServer *grpcapi.Server
+	if cfg.GRPCListenAddr != "" {
+		listener, err := net.Listen("tcp", cfg.GRPCListenAddr)
+		if err != nil {
+			errs <- err
+		} else {
+			grpcServer = grpcapi.NewServer(grpcapi.Config{
+				MaxIterations:       cfg.MaxIterations,
+				MaxConcurrent:       cfg.MaxConcurrent,
+				MaxStreamIterations: cfg.MaxJobIterations,
+				Workers:             workers,
+				Keys:                keys,
+				AllowAnonymous:      cfg.AllowAnonymous,
+				RateLimiter:         limiter,
+				TrustProxy:          cfg.TrustProxy,
+			})
+			slog.Info("starting gRPC server", "addr", cfg.GRPCListenAddr)
+			go func() {
+				errs <- grpcServer.Serve(listener)
+			}()
+		}
+	}
+
+	select {
+	case err := <-errs:
+		if grpcServer != nil {
+			grpcServer.Stop()
+		}
+		jobManager.Close()
+		keys.Close()
+		slog.Error("server stopped", "error", err)
+		os.Exit(1)
+	case <-signals.Done():
+		stop()
+	}
+
+	shutdown(server, grpcServer, probe, jobManager, cancelRequests, cfg.DrainDelay, cfg.ShutdownTimeout)
+
+	if err := keys.Close(); err != nil {
+		slog.Error("saving API key usage failed", "error", err)
+	}
+}
+
+// shutdown reports not ready for drainDelay so load balancers can stop
+// routing to the servers, cancels the simulation jobs, then stops accepting
+// connections and gives in-flight requests and calls until timeout to
+// finish before canceling them.
+func shutdown(server *http.Server, grpcServer *grpcapi.Server, probe *handlers.Probe, jobManager *jobs.Manager, cancelRequests context.CancelFunc, drainDelay, timeout time.Duration) {
+	slog.Info("shutting down", "drainDelay", drainDelay, "timeout", timeout)
+	probe.Drain()
+	grpcServer.Drain()
+	time.Sleep(drainDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	jobManager.Close()
+
+	grpcStopped := make(chan error, 1)
+	go func() {
+		grpcStopped <- grpcServer.Shutdown(ctx)
+	}()
+
+	if err := server.Shutdown(ctx); err != nil {
+		slog.Warn("in-flight requests did not finish in time, canceling them", "error", err)
+		cancelRequests()
+		server.Close()
+	}
+	if err := <-grpcStopped; err != nil {
+		slog.Warn("in-flight gRPC calls did not finish in time, canceled them", "error", err)
+	}
+
+	slog.Info("server stopped")
 }
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/apikeys"
+)
+
+type UsageResponse struct {
+	Keys []apikeys.Usage `json:"keys"`
+}
+
+// UsageHandler lists the usage of every API key. Only requests made with an
+// admin key may see it.
+func UsageHandler(config Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		if config.Keys == nil {
+			http.Error(w, "API keys are not enabled", http.StatusNotFound)
+			return
+		}
+
+		key, ok := apikeys.FromContext(r.Context())
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+			http.Error(w, "Invalid or missing API key", http.StatusUnauthorized)
+			return
+		}
+		if !key.Admin {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(UsageResponse{Keys: config.Keys.Usage()})
+	}
+}
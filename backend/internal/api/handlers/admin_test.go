@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/apikeys"
+)
+
+func TestUsageHandler(t *testing.T) {
+	store, err := apikeys.NewStore([]apikeys.Key{
+		{Name: "admin", Key: "root", Admin: true},
+		{Name: "partner", Key: "secret", IterationsPerDay: 500},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	config := testConfig
+	config.Keys = store
+
+	admin, _ := store.Authenticate("root")
+	partner, _ := store.Authenticate("secret")
+	asPartner := apikeys.WithKey(context.Background(), partner)
+
+	body := `{"playerCards": [{"Rank":14,"Suit":0},{"Rank":14,"Suit":1}], "numIterations": 400}`
+	for _, want := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		recorder := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/api/simulation", strings.NewReader(body)).WithContext(asPartner)
+		SimulationHander(config)(recorder, r)
+		if recorder.Code != want {
+			t.Errorf("simulation: status = %d, want %d", recorder.Code, want)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		wantStatus int
+	}{
+		{"Admin", apikeys.WithKey(context.Background(), admin), http.StatusOK},
+		{"Partner", asPartner, http.StatusForbidden},
+		{"Anonymous", context.Background(), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			UsageHandler(config)(recorder, httptest.NewRequest(http.MethodGet, "/api/admin/usage", nil).WithContext(tt.ctx))
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", recorder.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp UsageResponse
+			json.NewDecoder(recorder.Body).Decode(&resp)
+			if len(resp.Keys) != 2 || resp.Keys[1].Iterations != 400 || resp.Keys[1].Rejected != 1 {
+				t.Errorf("unexpected usage %+v", resp.Keys)
+			}
+		})
+	}
+}
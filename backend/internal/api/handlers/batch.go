@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/apikeys"
+	simulator "github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/simulation"
+)
+
+const (
+	maxBatchSize = 500
+	batchWorkers = 8
+)
+
+type BatchSimulationRequest struct {
+	Scenarios []SimulationRequest `json:"scenarios"`
+}
+
+// BatchSimulationResult holds either the result of one scenario or the
+// reason it could not be run.
+type BatchSimulationResult struct {
+	Result *SimulationResponse `json:"result,omitempty"`
+	Error  string              `json:"error,omitempty"`
+}
+
+type BatchSimulationResponse struct {
+	Results []BatchSimulationResult `json:"results"`
+}
+
+// BatchSimulationHandler runs every scenario on a shared pool of
+// batchWorkers workers, each simulating one scenario at a time on a single
+// goroutine, so a large batch cannot take over the machine. Results come
+// back in the order the scenarios were sent.
+func BatchSimulationHandler(config Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req BatchSimulationRequest
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+
+		if len(req.Scenarios) == 0 || len(req.Scenarios) > maxBatchSize {
+			http.Error(w, "Bad request", http.StatusBadRequest)
+			return
+		}
+
+		workers, ok := config.Workers.TryAcquire(min(batchWorkers, len(req.Scenarios)))
+		if !ok {
+			busy(w)
+			return
+		}
+		defer config.Workers.Release(workers)
+
+		// The batch holds its workers for its whole run, so the scenarios
+		// do not take any more from the pool.
+		itemConfig := config
+		itemConfig.Workers = nil
+
+		resp := BatchSimulationResponse{Results: runBatch(r.Context(), req.Scenarios, workers, itemConfig)}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func runBatch(ctx context.Context, scenarios []SimulationRequest, workers int, config Config) []BatchSimulationResult {
+	results := make([]BatchSimulationResult, len(scenarios))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range jobs {
+				results[index] = runBatchItem(ctx, scenarios[index], config)
+			}
+		}()
+	}
+
+	for index := range scenarios {
+		jobs <- index
+	}
+	close(jobs)
+
+	wg.Wait()
+
+	return results
+}
+
+func runBatchItem(ctx context.Context, scenario SimulationRequest, config Config) BatchSimulationResult {
+	scenario.NumConcurrent = 1
+
+	resp, err := runSimulation(ctx, scenario, config)
+
+	if simulator.IsInvalid(err) || errors.Is(err, apikeys.ErrQuotaExceeded) {
+		return BatchSimulationResult{Error: err.Error()}
+	}
+
+	if err != nil {
+		return BatchSimulationResult{Error: "Internal server error"}
+	}
+
+	return BatchSimulationResult{Result: &resp}
+}
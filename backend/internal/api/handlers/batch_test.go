@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/ratelimit"
+)
+
+var testConfig = Config{
+	MaxIterations:    10_000,
+	MaxConcurrent:    16,
+	MaxJobIterations: 1_000_000,
+}
+
+func TestBatchSimulationHandler(t *testing.T) {
+	body := `{"scenarios": [
+		{"playerCards": [{"Rank":14,"Suit":0},{"Rank":14,"Suit":1}], "numIterations": 200},
+		{"playerCards": [{"Rank":14,"Suit":0}]},
+		{"playerCards": [{"Rank":14,"Suit":0},{"Rank":14,"Suit":0}]},
+		{"playerCards": [{"Rank":7,"Suit":2},{"Rank":2,"Suit":3}], "numIterations": 200},
+		{"playerCards": [{"Rank":14,"Suit":0},{"Rank":14,"Suit":1}], "opponentCards": [{"Rank":2,"Suit":0},{"Rank":3,"Suit":0},{"Rank":4,"Suit":0}]}
+	]}`
+
+	recorder := httptest.NewRecorder()
+	BatchSimulationHandler(testConfig)(recorder, httptest.NewRequest(http.MethodPost, "/api/simulation/batch", strings.NewReader(body)))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", recorder.Code, recorder.Body)
+	}
+
+	var resp BatchSimulationResponse
+	if err := json.NewDecoder(recorder.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if len(resp.Results) != 5 {
+		t.Fatalf("expected 5 results, got %d", len(resp.Results))
+	}
+	if resp.Results[0].Result == nil || resp.Results[3].Result == nil {
+		t.Fatalf("valid scenarios failed: %+v", resp.Results)
+	}
+	if resp.Results[0].Result.WinProbability <= resp.Results[3].Result.WinProbability {
+		t.Errorf("results out of order: aces %v, seven-deuce %v",
+			resp.Results[0].Result.WinProbability, resp.Results[3].Result.WinProbability)
+	}
+	if resp.Results[1].Error == "" || resp.Results[2].Error == "" || resp.Results[4].Error == "" {
+		t.Errorf("expected errors for the malformed scenarios: %+v", resp.Results)
+	}
+}
+
+func TestBatchSimulationHandlerRejectsEmptyBatch(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	BatchSimulationHandler(testConfig)(recorder, httptest.NewRequest(http.MethodPost, "/api/simulation/batch", strings.NewReader(`{"scenarios": []}`)))
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusBadRequest)
+	}
+}
+
+func TestWorkerPoolFull(t *testing.T) {
+	pool := ratelimit.NewWorkerPool(4)
+	config := testConfig
+	config.MaxConcurrent = 4
+	config.Workers = pool
+
+	scenario := `{"playerCards": [{"Rank":14,"Suit":0},{"Rank":14,"Suit":1}], "numIterations": 200}`
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		body    string
+	}{
+		{"Simulation", SimulationHander(config), scenario},
+		{"Batch", BatchSimulationHandler(config), `{"scenarios": [` + scenario + `]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			tt.handler(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body)))
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("free pool: status = %d: %s", recorder.Code, recorder.Body)
+			}
+
+			taken, _ := pool.TryAcquire(4)
+			defer pool.Release(taken)
+
+			recorder = httptest.NewRecorder()
+			tt.handler(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body)))
+			if recorder.Code != http.StatusTooManyRequests || recorder.Header().Get("Retry-After") == "" {
+				t.Errorf("full pool: status = %d, Retry-After %q", recorder.Code, recorder.Header().Get("Retry-After"))
+			}
+		})
+	}
+
+	if pool.InUse() != 0 {
+		t.Errorf("%d workers were not released", pool.InUse())
+	}
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/apikeys"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/ratelimit"
+)
+
+// Config holds the limits the handlers enforce on simulation requests.
+// Workers, when set, is shared by every handler and caps the simulation
+// workers running at once. Keys, when set, is charged for the iterations
+// of requests made with an API key.
+type Config struct {
+	MaxIterations    int
+	MaxConcurrent    int
+	MaxJobIterations int
+	Workers          *ratelimit.WorkerPool
+	Keys             *apikeys.Store
+}
+
+var errBusy = errors.New("too many simulations running, try again later")
+
+// busy answers 429 when the shared worker pool is full.
+func busy(w http.ResponseWriter) {
+	w.Header().Set("Retry-After", "1")
+	http.Error(w, errBusy.Error(), http.StatusTooManyRequests)
+}
+
+// quotaExceeded answers 429 until the API key's quota starts over.
+func quotaExceeded(w http.ResponseWriter) {
+	now := time.Now()
+	wait := apikeys.QuotaResets(now).Sub(now)
+	w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+	http.Error(w, apikeys.ErrQuotaExceeded.Error(), http.StatusTooManyRequests)
+}
+
+// decodeJSON reads the request body into v, answering 413 if the body went
+// over the server's size limit and 400 if it is not valid JSON.
+func decodeJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	err := json.NewDecoder(r.Body).Decode(v)
+
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+		return false
+	}
+
+	if err != nil {
+		http.Error(w, "Bad request", http.StatusBadRequest)
+		return false
+	}
+
+	return true
+}
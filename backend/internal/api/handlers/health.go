@@ -3,6 +3,10 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"sync/atomic"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/jobs"
+	simulator "github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/simulation"
 )
 
 type HealthResponse struct {
@@ -15,7 +19,83 @@ func HealthCheckHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	w.Header().Set("Access-Control-Allow-Origin", "http://localhost:5173")
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(HealthResponse{Status: "ok"})
 }
+
+// Probe tracks whether the server should still be sent traffic. It starts
+// ready and stops being ready for good once Drain is called at shutdown.
+type Probe struct {
+	draining atomic.Bool
+}
+
+func NewProbe() *Probe {
+	return &Probe{}
+}
+
+func (p *Probe) Drain() {
+	p.draining.Store(true)
+}
+
+func (p *Probe) Ready() bool {
+	return !p.draining.Load()
+}
+
+// LivenessHandler answers as long as the process can serve requests at all.
+func LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	HealthCheckHandler(w, r)
+}
+
+type SimulatorCapacity struct {
+	ActiveWorkers int `json:"activeWorkers"`
+	WorkersInUse  int `json:"workersInUse"`
+	MaxWorkers    int `json:"maxWorkers,omitempty"`
+	MaxConcurrent int `json:"maxConcurrent"`
+	MaxIterations int `json:"maxIterations"`
+}
+
+type ReadinessResponse struct {
+	Status    string            `json:"status"`
+	Simulator SimulatorCapacity `json:"simulator"`
+	Jobs      *jobs.Stats       `json:"jobs,omitempty"`
+}
+
+// ReadinessHandler reports the simulator's capacity and answers 503 once
+// the server is draining or the job manager has been closed, so that load
+// balancers stop sending it new work.
+func ReadinessHandler(config Config, probe *Probe, manager *jobs.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		resp := ReadinessResponse{
+			Status: "ready",
+			Simulator: SimulatorCapacity{
+				ActiveWorkers: simulator.ActiveWorkers(),
+				WorkersInUse:  config.Workers.InUse(),
+				MaxWorkers:    config.Workers.Capacity(),
+				MaxConcurrent: config.MaxConcurrent,
+				MaxIterations: config.MaxIterations,
+			},
+		}
+
+		ready := probe == nil || probe.Ready()
+		if manager != nil {
+			stats := manager.Stats()
+			resp.Jobs = &stats
+			ready = ready && !stats.Closed
+		}
+
+		status := http.StatusOK
+		if !ready {
+			resp.Status = "draining"
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(resp)
+	}
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/jobs"
+)
+
+func TestReadinessHandler(t *testing.T) {
+	manager := jobs.NewManager(jobs.Config{Workers: 2, QueueSize: 4, TTL: time.Minute})
+	probe := NewProbe()
+	handler := ReadinessHandler(testConfig, probe, manager)
+
+	tests := []struct {
+		name       string
+		setup      func()
+		wantStatus int
+		wantBody   string
+	}{
+		{"Ready", func() {}, http.StatusOK, "ready"},
+		{"Draining", probe.Drain, http.StatusServiceUnavailable, "draining"},
+		{"Jobs closed", manager.Close, http.StatusServiceUnavailable, "draining"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.setup()
+
+			recorder := httptest.NewRecorder()
+			handler(recorder, httptest.NewRequest(http.MethodGet, "/api/health/ready", nil))
+
+			var resp ReadinessResponse
+			json.NewDecoder(recorder.Body).Decode(&resp)
+
+			if recorder.Code != tt.wantStatus || resp.Status != tt.wantBody {
+				t.Errorf("got %d %q, want %d %q", recorder.Code, resp.Status, tt.wantStatus, tt.wantBody)
+			}
+			if resp.Simulator.MaxConcurrent != testConfig.MaxConcurrent || resp.Jobs == nil || resp.Jobs.Workers != 2 || resp.Jobs.QueueSize != 4 {
+				t.Errorf("unexpected capacity %+v, jobs %+v", resp.Simulator, resp.Jobs)
+			}
+		})
+	}
+}
+
+func TestReadinessWithoutProbe(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	ReadinessHandler(testConfig, nil, nil)(recorder, httptest.NewRequest(http.MethodGet, "/api/health/ready", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", recorder.Code, http.StatusOK)
+	}
+}
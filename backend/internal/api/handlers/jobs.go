@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/apikeys"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/jobs"
+	simulator "github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/simulation"
+)
+
+// JobsHandler serves the asynchronous simulation API: POST /api/jobs queues
+// a simulation of up to config.MaxJobIterations iterations, GET /api/jobs/{id} reports
+// its status, progress and result so far, and DELETE /api/jobs/{id} cancels
+// it. A job is only visible to the API key that created it; other keys get
+// 404, as if it did not exist.
+func JobsHandler(config Config, manager *jobs.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/jobs"), "/")
+
+		if id != "" {
+			if job, ok := manager.Get(id); !ok || job.Owner != jobOwner(r.Context()) {
+				http.Error(w, "Job not found", http.StatusNotFound)
+				return
+			}
+		}
+
+		switch {
+		case id == "" && r.Method == http.MethodPost:
+			createJob(w, r, config, manager)
+		case id != "" && r.Method == http.MethodGet:
+			job, ok := manager.Get(id)
+			if !ok {
+				http.Error(w, "Job not found", http.StatusNotFound)
+				return
+			}
+			writeJob(w, http.StatusOK, job)
+		case id != "" && r.Method == http.MethodDelete:
+			job, ok := manager.Cancel(id)
+			if !ok {
+				http.Error(w, "Job not found", http.StatusNotFound)
+				return
+			}
+			writeJob(w, http.StatusOK, job)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func createJob(w http.ResponseWriter, r *http.Request, handlerConfig Config, manager *jobs.Manager) {
+	var req SimulationRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	config, err := simulator.Request(req).Config(handlerConfig.MaxJobIterations, handlerConfig.MaxConcurrent)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	total := config.NumIterations / config.NumConcurrent * config.NumConcurrent
+
+	if err := handlerConfig.Keys.ChargeContext(r.Context(), total); err != nil {
+		quotaExceeded(w)
+		return
+	}
+
+	job, err := manager.Submit(jobOwner(r.Context()), total, func(ctx context.Context, report func(jobs.Progress)) (any, error) {
+		workers, err := handlerConfig.Workers.Acquire(ctx, config.NumConcurrent)
+		if err != nil {
+			return nil, err
+		}
+		defer handlerConfig.Workers.Release(workers)
+		config.NumConcurrent = workers
+
+		result, err := simulator.NewSimulator(config).RunSimulationContext(ctx, func(partial *simulator.Result) {
+			report(jobs.Progress{Completed: partial.Iterations, Partial: simulationResponse(config, partial)})
+		})
+		if result == nil {
+			return nil, err
+		}
+		return simulationResponse(config, result), err
+	}, func(job jobs.Job) {
+		// The whole job was charged up front; give back what it never ran,
+		// whether it was canceled, failed or cut short by a shutdown.
+		if unused := total - job.Completed; unused > 0 {
+			handlerConfig.Keys.RefundContext(r.Context(), unused)
+		}
+	})
+
+	if err != nil {
+		handlerConfig.Keys.RefundContext(r.Context(), total)
+	}
+
+	if errors.Is(err, jobs.ErrQueueFull) || errors.Is(err, jobs.ErrClosed) {
+		w.Header().Set("Retry-After", "10")
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	if err != nil {
+		slog.ErrorContext(r.Context(), "submitting job failed", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	slog.InfoContext(r.Context(), "job created", "job", job.ID, "iterations", total)
+
+	w.Header().Set("Location", "/api/jobs/"+job.ID)
+	writeJob(w, http.StatusAccepted, job)
+}
+
+// jobOwner names the API key a request was made with, or is empty for
+// anonymous requests.
+func jobOwner(ctx context.Context) string {
+	key, _ := apikeys.FromContext(ctx)
+	return key.Name
+}
+
+func writeJob(w http.ResponseWriter, status int, job jobs.Job) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(job)
+}
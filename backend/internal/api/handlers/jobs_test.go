@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/apikeys"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/jobs"
+)
+
+func TestJobsHandler(t *testing.T) {
+	manager := jobs.NewManager(jobs.Config{Workers: 1, QueueSize: 1, TTL: time.Minute})
+	defer manager.Close()
+	handler := JobsHandler(testConfig, manager)
+
+	body := `{"playerCards": [{"Rank":14,"Suit":0},{"Rank":14,"Suit":1}], "numIterations": 20000, "numConcurrent": 2}`
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodPost, "/api/jobs", strings.NewReader(body)))
+
+	if recorder.Code != http.StatusAccepted {
+		t.Fatalf("status = %d: %s", recorder.Code, recorder.Body)
+	}
+
+	var job jobs.Job
+	json.NewDecoder(recorder.Body).Decode(&job)
+	if recorder.Header().Get("Location") != "/api/jobs/"+job.ID || job.Total != 20000 {
+		t.Errorf("unexpected job %+v at %q", job, recorder.Header().Get("Location"))
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for job.Status != jobs.Done && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		recorder = httptest.NewRecorder()
+		handler(recorder, httptest.NewRequest(http.MethodGet, "/api/jobs/"+job.ID, nil))
+		json.NewDecoder(recorder.Body).Decode(&job)
+	}
+
+	if job.Status != jobs.Done || job.Completed != 20000 {
+		t.Fatalf("job did not finish: %+v", job)
+	}
+	result, _ := job.Result.(map[string]any)
+	if result["iterations"] != float64(20000) {
+		t.Errorf("unexpected result %+v", job.Result)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodDelete, "/api/jobs/missing", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("cancel unknown job: status = %d", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodPost, "/api/jobs", strings.NewReader(`{"playerCards": []}`)))
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("bad scenario: status = %d", recorder.Code)
+	}
+}
+
+func TestJobsHandlerRefundsUnusedIterations(t *testing.T) {
+	store, err := apikeys.NewStore([]apikeys.Key{{Name: "partner", Key: "secret"}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	config := testConfig
+	config.Keys = store
+	partner, _ := store.Authenticate("secret")
+	asPartner := apikeys.WithKey(context.Background(), partner)
+
+	// Without workers every job waits in the queue until it is canceled or
+	// the manager shuts down.
+	manager := jobs.NewManager(jobs.Config{Workers: 0, QueueSize: 2, TTL: time.Minute})
+	handler := JobsHandler(config, manager)
+
+	submit := func() jobs.Job {
+		body := `{"playerCards": [{"Rank":14,"Suit":0},{"Rank":14,"Suit":1}], "numIterations": 20000, "numConcurrent": 2}`
+		recorder := httptest.NewRecorder()
+		handler(recorder, httptest.NewRequest(http.MethodPost, "/api/jobs", strings.NewReader(body)).WithContext(asPartner))
+		if recorder.Code != http.StatusAccepted {
+			t.Fatalf("status = %d: %s", recorder.Code, recorder.Body)
+		}
+		var job jobs.Job
+		json.NewDecoder(recorder.Body).Decode(&job)
+		return job
+	}
+
+	canceled := submit()
+	submit()
+	if usage := store.Usage(); usage[0].Iterations != 40000 {
+		t.Fatalf("iterations charged = %d, want 40000 for two queued jobs", usage[0].Iterations)
+	}
+
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/api/jobs/"+canceled.ID, nil).WithContext(asPartner))
+	if usage := store.Usage(); usage[0].Iterations != 20000 {
+		t.Errorf("iterations charged = %d, want 20000 after canceling a queued job", usage[0].Iterations)
+	}
+
+	manager.Close()
+	if usage := store.Usage(); usage[0].Iterations != 0 {
+		t.Errorf("iterations charged = %d, want 0 after shutting down", usage[0].Iterations)
+	}
+}
+
+func TestJobsHandlerHidesOtherKeysJobs(t *testing.T) {
+	store, err := apikeys.NewStore([]apikeys.Key{{Name: "partner", Key: "secret"}, {Name: "other", Key: "other-secret"}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	partner, _ := store.Authenticate("secret")
+	other, _ := store.Authenticate("other-secret")
+	asPartner := apikeys.WithKey(context.Background(), partner)
+	asOther := apikeys.WithKey(context.Background(), other)
+
+	manager := jobs.NewManager(jobs.Config{Workers: 0, QueueSize: 1, TTL: time.Minute})
+	defer manager.Close()
+	config := testConfig
+	config.Keys = store
+	handler := JobsHandler(config, manager)
+
+	body := `{"playerCards": [{"Rank":14,"Suit":0},{"Rank":14,"Suit":1}], "numIterations": 20000, "numConcurrent": 2}`
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodPost, "/api/jobs", strings.NewReader(body)).WithContext(asPartner))
+	var job jobs.Job
+	json.NewDecoder(recorder.Body).Decode(&job)
+
+	tests := []struct {
+		name   string
+		method string
+		ctx    context.Context
+		status int
+	}{
+		{"Other key reads", http.MethodGet, asOther, http.StatusNotFound},
+		{"Other key cancels", http.MethodDelete, asOther, http.StatusNotFound},
+		{"Anonymous reads", http.MethodGet, context.Background(), http.StatusNotFound},
+		{"Owner reads", http.MethodGet, asPartner, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler(recorder, httptest.NewRequest(tt.method, "/api/jobs/"+job.ID, nil).WithContext(tt.ctx))
+			if recorder.Code != tt.status {
+				t.Errorf("status = %d, want %d", recorder.Code, tt.status)
+			}
+		})
+	}
+
+	if current, _ := manager.Get(job.ID); current.Status != jobs.Queued {
+		t.Errorf("job is %q after other keys tried to cancel it", current.Status)
+	}
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/handhistory"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/replay"
+	simulator "github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/simulation"
+)
+
+// ReplayRequest takes either the raw text of a hand history, PokerStars or
+// Open Hand History JSON, or a hand that was already parsed. Only the first
+// hand of a history is replayed.
+type ReplayRequest struct {
+	History       string            `json:"history,omitempty"`
+	Hand          *handhistory.Hand `json:"hand,omitempty"`
+	NumIterations int               `json:"numIterations"`
+}
+
+type ReplayResponse struct {
+	HandID string        `json:"handId"`
+	Steps  []replay.Step `json:"steps"`
+}
+
+func ReplayHandler(config Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req ReplayRequest
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+
+		var hand handhistory.Hand
+		switch {
+		case req.Hand != nil:
+			hand = *req.Hand
+		case req.History != "":
+			parse := handhistory.ParsePokerStars
+			if strings.HasPrefix(strings.TrimSpace(req.History), "{") {
+				parse = handhistory.ParseOHH
+			}
+			hands, err := parse(strings.NewReader(req.History))
+			if len(hands) == 0 {
+				if err == nil {
+					err = errors.New("no hands found")
+				}
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			hand = hands[0]
+		default:
+			http.Error(w, "Bad request", http.StatusBadRequest)
+			return
+		}
+
+		if req.NumIterations <= 0 {
+			req.NumIterations = min(2_000, config.MaxIterations)
+		} else if req.NumIterations > config.MaxIterations {
+			req.NumIterations = config.MaxIterations
+		}
+
+		if err := replay.Validate(hand); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		workers, ok := config.Workers.TryAcquire(min(8, config.MaxConcurrent))
+		if !ok {
+			busy(w)
+			return
+		}
+		defer config.Workers.Release(workers)
+
+		// A replay is charged one simulation's worth of iterations, however
+		// many streets need their equity computed.
+		if err := config.Keys.ChargeContext(r.Context(), req.NumIterations); err != nil {
+			quotaExceeded(w)
+			return
+		}
+
+		steps, err := replay.Build(hand, replay.Options{NumIterations: req.NumIterations, NumConcurrent: workers})
+		if err != nil {
+			// A replay that fails part way returns nothing to pay for.
+			config.Keys.RefundContext(r.Context(), req.NumIterations)
+		}
+
+		if simulator.IsInvalid(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if err != nil {
+			slog.ErrorContext(r.Context(), "replay failed", "hand", hand.ID, "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		body, err := json.Marshal(ReplayResponse{HandID: hand.ID, Steps: steps})
+		if err != nil {
+			slog.ErrorContext(r.Context(), "encoding replay failed", "hand", hand.ID, "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}
+}
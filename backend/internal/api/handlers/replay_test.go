@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/apikeys"
+)
+
+func TestReplayHandler(t *testing.T) {
+	history, err := os.ReadFile("../../handhistory/testdata/cash.txt")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	request := func(history string) string {
+		body, _ := json.Marshal(ReplayRequest{History: history, NumIterations: 500})
+		return string(body)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		body   string
+		status int
+	}{
+		{"Wrong method", http.MethodGet, "", http.StatusMethodNotAllowed},
+		{"Invalid JSON", http.MethodPost, "{", http.StatusBadRequest},
+		{"No hand", http.MethodPost, `{"numIterations": 500}`, http.StatusBadRequest},
+		{"Unparsable history", http.MethodPost, request("not a hand history"), http.StatusBadRequest},
+		{"Card dealt twice", http.MethodPost, request(strings.ReplaceAll(string(history), "[Ah Kd]", "[Ah Ac]")), http.StatusBadRequest},
+		{"Hand", http.MethodPost, request(string(history)), http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			ReplayHandler(testConfig)(recorder, httptest.NewRequest(tt.method, "/api/replay", strings.NewReader(tt.body)))
+
+			if recorder.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, tt.status, recorder.Body)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var resp ReplayResponse
+			if err := json.NewDecoder(recorder.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp.HandID != "208915563282" || len(resp.Steps) == 0 {
+				t.Errorf("replay of hand %q has %d steps", resp.HandID, len(resp.Steps))
+			}
+		})
+	}
+}
+
+func TestReplayHandlerChargesOnlyReplays(t *testing.T) {
+	history, err := os.ReadFile("../../handhistory/testdata/cash.txt")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+
+	store, err := apikeys.NewStore([]apikeys.Key{{Name: "partner", Key: "secret"}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	config := testConfig
+	config.Keys = store
+	partner, _ := store.Authenticate("secret")
+	asPartner := apikeys.WithKey(context.Background(), partner)
+
+	for _, tt := range []struct {
+		history string
+		status  int
+	}{
+		{strings.ReplaceAll(string(history), "[Ah Kd]", "[Ah Ac]"), http.StatusBadRequest},
+		{string(history), http.StatusOK},
+	} {
+		body, _ := json.Marshal(ReplayRequest{History: tt.history, NumIterations: 500})
+		recorder := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/api/replay", strings.NewReader(string(body))).WithContext(asPartner)
+		ReplayHandler(config)(recorder, r)
+
+		if recorder.Code != tt.status {
+			t.Fatalf("status = %d, want %d: %s", recorder.Code, tt.status, recorder.Body)
+		}
+	}
+
+	if usage := store.Usage(); usage[0].Iterations != 500 {
+		t.Errorf("iterations charged = %d, want 500 for the one replay", usage[0].Iterations)
+	}
+}
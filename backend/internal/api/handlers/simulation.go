@@ -1,10 +1,14 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
+	"errors"
+	"log/slog"
 	"net/http"
 
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/apikeys"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/logging"
 	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
 	simulator "github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/simulation"
 )
@@ -13,6 +17,7 @@ type SimulationRequest struct {
 	PlayerCards    []poker.Card `json:"playerCards"`
 	OpponentCards  []poker.Card `json:"opponentCards,omitempty"`
 	CommunityCards []poker.Card `json:"communityCards,omitempty"`
+	DeadCards      []poker.Card `json:"deadCards,omitempty"`
 	NumIterations  int          `json:"numIterations"`
 	NumConcurrent  int          `json:"numConcurrent"`
 }
@@ -22,90 +27,93 @@ type SimulationResponse struct {
 	LoseProbability float64 `json:"loseProbability"`
 	TieProbability  float64 `json:"tieProbability"`
 	Iterations      int     `json:"iterations"`
+	PlayerHand      string  `json:"playerHand"`
 }
 
-func SimulationHander(w http.ResponseWriter, r *http.Request) {
-	allowedOrigins := []string{"http://localhost:5173",
-		"http://localhost:4173",
-		"https://texas-holdem-calculator.onrender.com"}
+func SimulationHander(config Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
 
-	origin := r.Header.Get("Origin")
-	for _, allowedOrigin := range allowedOrigins {
-		if origin == allowedOrigin {
-			w.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
-			break
+		var req SimulationRequest
+		if !decodeJSON(w, r, &req) {
+			return
 		}
-	}
 
-	w.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		resp, err := runSimulation(r.Context(), req, config)
 
-	if r.Method == "OPTIONS" {
-		w.WriteHeader(http.StatusOK)
-		return
-	}
+		if errors.Is(err, errBusy) {
+			busy(w)
+			return
+		}
 
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
+		if errors.Is(err, apikeys.ErrQuotaExceeded) {
+			quotaExceeded(w)
+			return
+		}
 
-	var req SimulationRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Bad request", http.StatusBadRequest)
-		return
-	}
+		if simulator.IsInvalid(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 
-	if len(req.PlayerCards) != 2 {
-		http.Error(w, "Bad request", http.StatusBadRequest)
-		return
-	}
+		if err != nil {
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
 
-	if len(req.CommunityCards) > 5 {
-		http.Error(w, "Bad request", http.StatusBadRequest)
-		return
-	}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
 
-	if req.NumIterations <= 0 {
-		req.NumIterations = 10_000
-	} else if req.NumIterations > 10_000 {
-		req.NumIterations = 10_000
 	}
+}
 
-	if req.NumConcurrent <= 0 {
-		req.NumConcurrent = 8
-	} else if req.NumConcurrent > 16 {
-		req.NumConcurrent = 16
+func runSimulation(ctx context.Context, req SimulationRequest, handlerConfig Config) (SimulationResponse, error) {
+	config, err := simulator.Request(req).Config(handlerConfig.MaxIterations, handlerConfig.MaxConcurrent)
+	if err != nil {
+		slog.DebugContext(ctx, "rejected simulation", "error", err)
+		return SimulationResponse{}, err
 	}
 
-	fmt.Printf("Request - Player cards: %v, Opponent cards: %v Community cards: %v, Iterations: %d\n",
-		req.PlayerCards, req.OpponentCards, req.CommunityCards, req.NumIterations)
+	slog.DebugContext(ctx, "simulation request",
+		logging.Cards(logging.PlayerCardsKey, req.PlayerCards),
+		logging.Cards(logging.OpponentCardsKey, req.OpponentCards),
+		logging.Cards(logging.CommunityCardsKey, req.CommunityCards),
+		logging.Cards(logging.DeadCardsKey, req.DeadCards),
+		"iterations", config.NumIterations,
+		"workers", config.NumConcurrent,
+	)
+
+	workers, ok := handlerConfig.Workers.TryAcquire(config.NumConcurrent)
+	if !ok {
+		return SimulationResponse{}, errBusy
+	}
+	defer handlerConfig.Workers.Release(workers)
+	config.NumConcurrent = workers
 
-	config := simulator.Config{
-		PlayerHand:     poker.NewHand(req.PlayerCards...),
-		OpponentHand:   poker.NewHand(req.OpponentCards...),
-		CommunityCards: req.CommunityCards,
-		NumIterations:  req.NumIterations,
-		NumConcurrent:  req.NumConcurrent,
+	if err := handlerConfig.Keys.ChargeContext(ctx, config.NumIterations); err != nil {
+		return SimulationResponse{}, err
 	}
 
 	sim := simulator.NewSimulator(config)
 
-	result, err := sim.RunSimulation()
-
+	result, err := sim.RunSimulationContext(ctx, nil)
 	if err != nil {
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-		return
+		slog.ErrorContext(ctx, "simulation failed", "error", err)
+		return SimulationResponse{}, err
 	}
 
-	resp := SimulationResponse{
+	return simulationResponse(config, result), nil
+}
+
+func simulationResponse(config simulator.Config, result *simulator.Result) SimulationResponse {
+	return SimulationResponse{
 		WinProbability:  result.WinProbability,
 		LoseProbability: result.LoseProbability,
 		TieProbability:  result.TieProbability,
 		Iterations:      result.Iterations,
+		PlayerHand:      config.PlayerHand.EvaluateHandStrenght(config.CommunityCards).Describe(),
 	}
-
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(resp)
-
 }
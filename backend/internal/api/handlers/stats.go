@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/handhistory"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/stats"
+)
+
+func StatsHandler(config Config, hands []handhistory.Hand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		player := r.URL.Query().Get("player")
+		if player == "" {
+			http.Error(w, "Bad request", http.StatusBadRequest)
+			return
+		}
+
+		playerStats, ok := stats.ComputePlayer(hands, player)
+		if !ok {
+			http.Error(w, "Player not found", http.StatusNotFound)
+			return
+		}
+
+		switch r.URL.Query().Get("format") {
+		case "", "json":
+			w.Header().Set("Content-Type", "application/json")
+			stats.WriteJSON(w, []stats.PlayerStats{playerStats})
+		case "csv":
+			w.Header().Set("Content-Type", "text/csv")
+			stats.WriteCSV(w, []stats.PlayerStats{playerStats})
+		default:
+			http.Error(w, "Bad request", http.StatusBadRequest)
+		}
+	}
+}
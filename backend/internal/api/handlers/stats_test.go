@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/handhistory"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/stats"
+)
+
+func loadHands(t *testing.T) []handhistory.Hand {
+	t.Helper()
+
+	file, err := os.Open("../../handhistory/testdata/cash.txt")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer file.Close()
+
+	hands, err := handhistory.ParsePokerStars(file)
+	if err != nil {
+		t.Fatalf("ParsePokerStars() error = %v", err)
+	}
+	return hands
+}
+
+func TestStatsHandler(t *testing.T) {
+	hands := loadHands(t)
+
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		hands       []handhistory.Hand
+		status      int
+		contentType string
+	}{
+		{"Wrong method", http.MethodPost, "/api/stats?player=hero", hands, http.StatusMethodNotAllowed, ""},
+		{"No player", http.MethodGet, "/api/stats", hands, http.StatusBadRequest, ""},
+		{"No hands loaded", http.MethodGet, "/api/stats?player=hero", nil, http.StatusNotFound, ""},
+		{"Unknown player", http.MethodGet, "/api/stats?player=nobody", hands, http.StatusNotFound, ""},
+		{"Unknown format", http.MethodGet, "/api/stats?player=hero&format=xml", hands, http.StatusBadRequest, ""},
+		{"JSON", http.MethodGet, "/api/stats?player=hero", hands, http.StatusOK, "application/json"},
+		{"CSV", http.MethodGet, "/api/stats?player=hero&format=csv", hands, http.StatusOK, "text/csv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			StatsHandler(testConfig, tt.hands)(recorder, httptest.NewRequest(tt.method, tt.target, nil))
+
+			if recorder.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", recorder.Code, tt.status, recorder.Body)
+			}
+			if got := recorder.Header().Get("Content-Type"); tt.contentType != "" && got != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.contentType)
+			}
+		})
+	}
+
+	recorder := httptest.NewRecorder()
+	StatsHandler(testConfig, hands)(recorder, httptest.NewRequest(http.MethodGet, "/api/stats?player=hero", nil))
+
+	var playerStats []stats.PlayerStats
+	if err := json.NewDecoder(recorder.Body).Decode(&playerStats); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(playerStats) != 1 || playerStats[0].Player != "hero" || playerStats[0].Hands != 2 {
+		t.Errorf("stats = %+v", playerStats)
+	}
+
+	recorder = httptest.NewRecorder()
+	StatsHandler(testConfig, hands)(recorder, httptest.NewRequest(http.MethodGet, "/api/stats?player=hero&format=csv", nil))
+	if lines := strings.Split(strings.TrimSpace(recorder.Body.String()), "\n"); len(lines) != 2 || !strings.HasPrefix(lines[1], "hero,") {
+		t.Errorf("csv = %q", recorder.Body)
+	}
+}
@@ -0,0 +1,269 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"regexp"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/apikeys"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/logging"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/metrics"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/ratelimit"
+)
+
+type Middleware func(http.Handler) http.Handler
+
+// Chain wraps handler so that the first middleware listed sees the request
+// first.
+func Chain(handler http.Handler, middlewares ...Middleware) http.Handler {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// CORS allows cross-origin requests from the listed origins and answers
+// their preflight requests without passing them on.
+func CORS(allowedOrigins []string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Origin")
+
+			origin := r.Header.Get("Origin")
+			allowed := origin != "" && slices.Contains(allowedOrigins, origin)
+			if allowed {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID, Location, Retry-After")
+			}
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				if allowed {
+					w.Header().Set("Access-Control-Allow-Methods", "GET, POST, DELETE, OPTIONS")
+					w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-Request-ID, X-API-Key, Authorization")
+					w.Header().Set("Access-Control-Max-Age", "600")
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// RequestID tags every request with an ID, reusing a well-formed
+// X-Request-ID sent by the client, and echoes it in the response.
+func RequestID() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get("X-Request-ID")
+			if !validRequestID.MatchString(id) {
+				b := make([]byte, 8)
+				rand.Read(b)
+				id = hex.EncodeToString(b)
+			}
+
+			w.Header().Set("X-Request-ID", id)
+			next.ServeHTTP(w, r.WithContext(logging.WithRequestID(r.Context(), id)))
+		})
+	}
+}
+
+func RequestIDFrom(ctx context.Context) string {
+	return logging.RequestID(ctx)
+}
+
+// Recover turns a panicking handler into a 500 response instead of a
+// dropped connection.
+func Recover() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					if recovered == http.ErrAbortHandler {
+						panic(recovered)
+					}
+					slog.ErrorContext(r.Context(), "panic serving request",
+						"method", r.Method,
+						"path", r.URL.Path,
+						"panic", fmt.Sprint(recovered),
+						"stack", string(debug.Stack()),
+					)
+					http.Error(w, "Internal server error", http.StatusInternalServerError)
+				}
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// statusRecorder remembers the status code written through it.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	if s.status == 0 {
+		s.status = status
+	}
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *statusRecorder) Write(b []byte) (int, error) {
+	if s.status == 0 {
+		s.status = http.StatusOK
+	}
+	n, err := s.ResponseWriter.Write(b)
+	s.bytes += n
+	return n, err
+}
+
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
+// Logger logs every request once it has been served.
+func Logger() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := &statusRecorder{ResponseWriter: w}
+
+			next.ServeHTTP(recorder, r)
+
+			if recorder.status == 0 {
+				recorder.status = http.StatusOK
+			}
+			level := slog.LevelInfo
+			if recorder.status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			slog.Log(r.Context(), level, "request",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", recorder.status,
+				"bytes", recorder.bytes,
+				"duration", time.Since(start),
+			)
+		})
+	}
+}
+
+var (
+	requestsTotal = metrics.Default.NewCounterVec("http_requests_total",
+		"HTTP requests served, by route, method and status.", "route", "method", "status")
+	requestDuration = metrics.Default.NewHistogramVec("http_request_duration_seconds",
+		"Time taken to serve HTTP requests, by route and method.", metrics.DefBuckets, "route", "method")
+	requestsInFlight = metrics.Default.NewGauge("http_requests_in_flight",
+		"HTTP requests being served.")
+)
+
+// Metrics counts and times every request. Requests are labelled with the
+// route returned by route, the pattern they matched rather than their path,
+// so that IDs in paths do not create a series each.
+func Metrics(route func(r *http.Request) string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := &statusRecorder{ResponseWriter: w}
+
+			requestsInFlight.Inc()
+			defer requestsInFlight.Dec()
+
+			next.ServeHTTP(recorder, r)
+
+			if recorder.status == 0 {
+				recorder.status = http.StatusOK
+			}
+			name := route(r)
+			if name == "" {
+				name = "unmatched"
+			}
+			requestsTotal.With(name, r.Method, strconv.Itoa(recorder.status)).Inc()
+			requestDuration.With(name, r.Method).Observe(time.Since(start).Seconds())
+		})
+	}
+}
+
+// APIKey authenticates requests by their X-API-Key header or bearer token
+// and stores the key in the request context. An unknown key gets 401, and
+// so does a request without a key unless allowAnonymous is set. A nil store
+// lets every request through. Failed attempts are charged to the client's
+// bucket in limiter, which is its IP address, so guessing keys is rate
+// limited like any other anonymous request.
+func APIKey(store *apikeys.Store, allowAnonymous bool, limiter *ratelimit.Limiter, clientKey func(r *http.Request) string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			secret := apikeys.Secret(r.Header.Get("X-API-Key"), r.Header.Get("Authorization"))
+
+			ctx, ok := store.AuthenticateContext(r.Context(), secret, allowAnonymous)
+			if !ok {
+				if allowed, wait := limiter.Allow(clientKey(r), time.Now()); !allowed {
+					tooManyRequests(w, wait)
+					return
+				}
+				w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+				http.Error(w, "Invalid or missing API key", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ClientKey identifies the client behind a request by its API key, or else
+// by its IP address, as apikeys.ClientKey does.
+func ClientKey(trustProxy bool) func(r *http.Request) string {
+	return func(r *http.Request) string {
+		return apikeys.ClientKey(r.Context(), r.RemoteAddr, r.Header.Values("X-Forwarded-For"), trustProxy)
+	}
+}
+
+// RateLimit answers 429 with Retry-After once the client named by key has
+// used up its tokens.
+func RateLimit(limiter *ratelimit.Limiter, key func(r *http.Request) string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ok, wait := limiter.Allow(key(r), time.Now()); !ok {
+				tooManyRequests(w, wait)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func tooManyRequests(w http.ResponseWriter, wait time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	http.Error(w, "Too many requests", http.StatusTooManyRequests)
+}
+
+// MaxBodySize limits request bodies to limit bytes. Reading past the limit
+// fails with an *http.MaxBytesError.
+func MaxBodySize(limit int64) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > limit {
+				http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,275 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/apikeys"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/ratelimit"
+)
+
+var ok = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	io.WriteString(w, RequestIDFrom(r.Context()))
+})
+
+func TestCORS(t *testing.T) {
+	handler := CORS([]string{"https://allowed.example"})(ok)
+
+	tests := []struct {
+		name       string
+		method     string
+		origin     string
+		preflight  bool
+		wantStatus int
+		wantOrigin string
+	}{
+		{"Allowed origin", http.MethodPost, "https://allowed.example", false, http.StatusOK, "https://allowed.example"},
+		{"Other origin", http.MethodPost, "https://evil.example", false, http.StatusOK, ""},
+		{"Allowed preflight", http.MethodOptions, "https://allowed.example", true, http.StatusNoContent, "https://allowed.example"},
+		{"Rejected preflight", http.MethodOptions, "https://evil.example", true, http.StatusNoContent, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, "/api/simulation", nil)
+			r.Header.Set("Origin", tt.origin)
+			if tt.preflight {
+				r.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantOrigin)
+			}
+			if tt.preflight && tt.wantOrigin != "" && w.Header().Get("Access-Control-Allow-Methods") == "" {
+				t.Errorf("preflight is missing Access-Control-Allow-Methods")
+			}
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	handler := RequestID()(ok)
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Request-ID", "client-id-1")
+	handler.ServeHTTP(w, r)
+
+	if w.Header().Get("X-Request-ID") != "client-id-1" || w.Body.String() != "client-id-1" {
+		t.Errorf("client request ID not kept: header %q, context %q", w.Header().Get("X-Request-ID"), w.Body)
+	}
+
+	w = httptest.NewRecorder()
+	r = httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Request-ID", "bad id\nwith newline")
+	handler.ServeHTTP(w, r)
+
+	if id := w.Header().Get("X-Request-ID"); len(id) != 16 || id != w.Body.String() {
+		t.Errorf("expected a generated request ID, got header %q, context %q", id, w.Body)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	handler := Chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}), RequestID(), Recover())
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestMaxBodySize(t *testing.T) {
+	handler := MaxBodySize(8)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		}
+	}))
+
+	for _, body := range []string{"small", "much more than eight bytes"} {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		r.ContentLength = -1
+		handler.ServeHTTP(w, r)
+
+		want := http.StatusOK
+		if len(body) > 8 {
+			want = http.StatusRequestEntityTooLarge
+		}
+		if w.Code != want {
+			t.Errorf("body %q: status = %d, want %d", body, w.Code, want)
+		}
+	}
+}
+
+func TestChainOrder(t *testing.T) {
+	var order []string
+	tag := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	Chain(ok, tag("first"), tag("second")).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if strings.Join(order, ",") != "first,second" {
+		t.Errorf("order = %v", order)
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	handler := Metrics(func(r *http.Request) string { return "/api/jobs/" })(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Job not found", http.StatusNotFound)
+	}))
+
+	counter := requestsTotal.With("/api/jobs/", http.MethodGet, "404")
+	before := counter.Value()
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/jobs/abc", nil))
+
+	if got := counter.Value() - before; got != 1 {
+		t.Errorf("requests counted = %v, want 1", got)
+	}
+	if got := requestsInFlight.Value(); got != 0 {
+		t.Errorf("requests in flight = %v, want 0", got)
+	}
+}
+
+func TestClientKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		trustProxy bool
+		forwarded  []string
+		want       string
+	}{
+		{"Remote address", false, nil, "ip:192.0.2.1"},
+		{"Untrusted proxy header", false, []string{"203.0.113.9"}, "ip:192.0.2.1"},
+		{"Trusted proxy", true, []string{"198.51.100.7, 203.0.113.9"}, "ip:203.0.113.9"},
+		{"Trusted proxy, several headers", true, []string{"198.51.100.7", "203.0.113.9"}, "ip:203.0.113.9"},
+		{"Trusted proxy, no header", true, nil, "ip:192.0.2.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.RemoteAddr = "192.0.2.1:5555"
+			for _, value := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", value)
+			}
+
+			if got := ClientKey(tt.trustProxy)(r); got != tt.want {
+				t.Errorf("ClientKey() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	handler := RateLimit(ratelimit.NewLimiter(1, 2), ClientKey(false))(ok)
+
+	for i, want := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if w.Code != want {
+			t.Errorf("request %d: status = %d, want %d", i+1, w.Code, want)
+		}
+		if want == http.StatusTooManyRequests && w.Header().Get("Retry-After") != "1" {
+			t.Errorf("Retry-After = %q, want 1", w.Header().Get("Retry-After"))
+		}
+	}
+}
+
+func TestAPIKey(t *testing.T) {
+	store, err := apikeys.NewStore([]apikeys.Key{{Name: "partner", Key: "secret"}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	named := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, ClientKey(false)(r))
+	})
+
+	tests := []struct {
+		name           string
+		allowAnonymous bool
+		header         string
+		value          string
+		wantStatus     int
+		wantClient     string
+	}{
+		{"Key header", false, "X-API-Key", "secret", http.StatusOK, "key:partner"},
+		{"Bearer token", false, "Authorization", "Bearer secret", http.StatusOK, "key:partner"},
+		{"Unknown key", true, "X-API-Key", "guess", http.StatusUnauthorized, ""},
+		{"Anonymous allowed", true, "", "", http.StatusOK, "ip:192.0.2.1"},
+		{"Anonymous refused", false, "", "", http.StatusUnauthorized, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				r.Header.Set(tt.header, tt.value)
+			}
+
+			w := httptest.NewRecorder()
+			APIKey(store, tt.allowAnonymous, nil, ClientKey(false))(named).ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK && w.Body.String() != tt.wantClient {
+				t.Errorf("client = %q, want %q", w.Body, tt.wantClient)
+			}
+		})
+	}
+
+	if usage := store.Usage(); usage[0].Requests != 2 {
+		t.Errorf("requests counted = %d, want 2", usage[0].Requests)
+	}
+}
+
+func TestAPIKeyLimitsFailures(t *testing.T) {
+	store, err := apikeys.NewStore([]apikeys.Key{{Name: "partner", Key: "secret"}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	handler := APIKey(store, false, ratelimit.NewLimiter(0.001, 2), ClientKey(false))(ok)
+
+	for i, tt := range []struct {
+		key  string
+		want int
+	}{
+		{"guess", http.StatusUnauthorized},
+		{"", http.StatusUnauthorized},
+		{"guess", http.StatusTooManyRequests},
+		{"secret", http.StatusOK},
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("X-API-Key", tt.key)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		if w.Code != tt.want {
+			t.Errorf("request %d: status = %d, want %d", i+1, w.Code, tt.want)
+		}
+	}
+}
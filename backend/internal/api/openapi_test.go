@@ -0,0 +1,221 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/client"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/api/handlers"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/apikeys"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/handhistory"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/jobs"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/replay"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/stats"
+)
+
+type spec struct {
+	OpenAPI    string                                `json:"openapi"`
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]struct {
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+func loadSpec(t *testing.T) spec {
+	t.Helper()
+
+	var s spec
+	if err := json.Unmarshal(OpenAPI, &s); err != nil {
+		t.Fatalf("openapi.json is not valid JSON: %v", err)
+	}
+	if !strings.HasPrefix(s.OpenAPI, "3.") {
+		t.Fatalf("openapi = %q, want 3.x", s.OpenAPI)
+	}
+	return s
+}
+
+// jsonFields lists the names a struct's exported fields have in JSON.
+func jsonFields(typ reflect.Type) []string {
+	var names []string
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous {
+			names = append(names, jsonFields(field.Type)...)
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func TestOpenAPIPathsMatchRoutes(t *testing.T) {
+	s := loadSpec(t)
+
+	var documented []string
+	for path := range s.Paths {
+		pattern, _, _ := strings.Cut(path, "{")
+		documented = append(documented, pattern)
+	}
+	var registered []string
+	for _, r := range routes(Options{}) {
+		registered = append(registered, r.pattern)
+	}
+
+	for _, pattern := range registered {
+		if !slices.Contains(documented, pattern) {
+			t.Errorf("route %s is not in openapi.json", pattern)
+		}
+	}
+	for _, pattern := range documented {
+		if !slices.Contains(registered, pattern) {
+			t.Errorf("openapi.json documents %s, which is not a route", pattern)
+		}
+	}
+}
+
+func TestOpenAPIMethodsAreServed(t *testing.T) {
+	s := loadSpec(t)
+
+	keys, err := apikeys.NewStore(nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer keys.Close()
+
+	manager := jobs.NewManager(jobs.Config{Workers: 1, QueueSize: 1, TTL: 1})
+	defer manager.Close()
+
+	router := NewRouter(Options{
+		Handlers:       handlers.Config{MaxIterations: 100, MaxConcurrent: 1, MaxJobIterations: 100, Keys: keys},
+		MaxBodyBytes:   1 << 10,
+		Jobs:           manager,
+		AllowAnonymous: true,
+	})
+
+	for path, operations := range s.Paths {
+		for method := range operations {
+			url := strings.ReplaceAll(path, "{id}", "missing")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(strings.ToUpper(method), url, strings.NewReader("{}")))
+
+			if w.Code == http.StatusMethodNotAllowed {
+				t.Errorf("%s %s is documented but answers %d", strings.ToUpper(method), path, w.Code)
+			}
+		}
+	}
+}
+
+func TestOpenAPISchemasMatchTypes(t *testing.T) {
+	s := loadSpec(t)
+
+	types := map[string]any{
+		"Card":                    poker.Card{},
+		"SimulationRequest":       handlers.SimulationRequest{},
+		"SimulationResponse":      handlers.SimulationResponse{},
+		"BatchSimulationRequest":  handlers.BatchSimulationRequest{},
+		"BatchSimulationResult":   handlers.BatchSimulationResult{},
+		"BatchSimulationResponse": handlers.BatchSimulationResponse{},
+		"Job":                     jobs.Job{},
+		"JobStats":                jobs.Stats{},
+		"HealthResponse":          handlers.HealthResponse{},
+		"SimulatorCapacity":       handlers.SimulatorCapacity{},
+		"ReadinessResponse":       handlers.ReadinessResponse{},
+		"Ratio":                   stats.Ratio{},
+		"PlayerStats":             stats.PlayerStats{},
+		"Action":                  handhistory.Action{},
+		"Seat":                    handhistory.Seat{},
+		"Hand":                    handhistory.Hand{},
+		"ReplayRequest":           handlers.ReplayRequest{},
+		"Step":                    replay.Step{},
+		"ReplayResponse":          handlers.ReplayResponse{},
+		"Usage":                   apikeys.Usage{},
+		"UsageResponse":           handlers.UsageResponse{},
+	}
+
+	checkSchemas(t, s, types)
+}
+
+// TestOpenAPISchemasMatchClientTypes keeps the public client's copies of the
+// types in step with the document.
+func TestOpenAPISchemasMatchClientTypes(t *testing.T) {
+	s := loadSpec(t)
+
+	types := map[string]any{
+		"Card":                    client.Card{},
+		"SimulationRequest":       client.SimulationRequest{},
+		"SimulationResponse":      client.SimulationResponse{},
+		"BatchSimulationRequest":  client.BatchSimulationRequest{},
+		"BatchSimulationResult":   client.BatchSimulationResult{},
+		"BatchSimulationResponse": client.BatchSimulationResponse{},
+		"Job":                     client.Job{},
+		"JobStats":                client.JobStats{},
+		"HealthResponse":          client.HealthResponse{},
+		"SimulatorCapacity":       client.SimulatorCapacity{},
+		"ReadinessResponse":       client.ReadinessResponse{},
+		"Ratio":                   client.Ratio{},
+		"PlayerStats":             client.PlayerStats{},
+		"Action":                  client.Action{},
+		"Seat":                    client.Seat{},
+		"Hand":                    client.Hand{},
+		"ReplayRequest":           client.ReplayRequest{},
+		"Step":                    client.Step{},
+		"ReplayResponse":          client.ReplayResponse{},
+		"Usage":                   client.Usage{},
+		"UsageResponse":           client.UsageResponse{},
+	}
+
+	checkSchemas(t, s, types)
+}
+
+// checkSchemas compares every schema with properties to the JSON fields of
+// the Go type of the same name.
+func checkSchemas(t *testing.T, s spec, types map[string]any) {
+	t.Helper()
+
+	for name, schema := range s.Components.Schemas {
+		if schema.Properties == nil {
+			continue
+		}
+		value, ok := types[name]
+		if !ok {
+			t.Errorf("schema %s is not checked against a Go type", name)
+			continue
+		}
+
+		var properties []string
+		for property := range schema.Properties {
+			properties = append(properties, property)
+		}
+		slices.Sort(properties)
+
+		if fields := jsonFields(reflect.TypeOf(value)); !slices.Equal(properties, fields) {
+			t.Errorf("schema %s has properties %v, but %T has %v", name, properties, value, fields)
+		}
+	}
+
+	for name := range types {
+		if _, ok := s.Components.Schemas[name]; !ok {
+			t.Errorf("no schema %s in openapi.json", name)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"io/fs"
+	"net/http"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/api/handlers"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/api/middleware"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/handhistory"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/jobs"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/metrics"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/ratelimit"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/web"
+)
+
+type Options struct {
+	Handlers       handlers.Config
+	AllowedOrigins []string
+	MaxBodyBytes   int64
+	Hands          []handhistory.Hand
+	Jobs           *jobs.Manager
+	Probe          *handlers.Probe
+	RateLimiter    *ratelimit.Limiter
+	TrustProxy     bool
+	AllowAnonymous bool
+	// Frontend, when set, is the built frontend, served on every path the
+	// API does not use.
+	Frontend fs.FS
+}
+
+type route struct {
+	pattern string
+	handler http.Handler
+}
+
+// routes lists every endpoint. Health checks, metrics, the OpenAPI document
+// and the frontend need no API key and are not rate limited.
+func routes(options Options) []route {
+	clientKey := middleware.ClientKey(options.TrustProxy)
+	authenticate := middleware.APIKey(options.Handlers.Keys, options.AllowAnonymous, options.RateLimiter, clientKey)
+	rateLimit := middleware.RateLimit(options.RateLimiter, clientKey)
+	limit := func(handler http.Handler) http.Handler {
+		return authenticate(rateLimit(handler))
+	}
+
+	list := []route{
+		{"/api/health", http.HandlerFunc(handlers.HealthCheckHandler)},
+		{"/api/health/live", http.HandlerFunc(handlers.LivenessHandler)},
+		{"/api/health/ready", handlers.ReadinessHandler(options.Handlers, options.Probe, options.Jobs)},
+		{"/api/simulation", limit(handlers.SimulationHander(options.Handlers))},
+		{"/api/simulation/batch", limit(handlers.BatchSimulationHandler(options.Handlers))},
+		{"/api/stats", limit(handlers.StatsHandler(options.Handlers, options.Hands))},
+		{"/api/replay", limit(handlers.ReplayHandler(options.Handlers))},
+		{"/api/jobs", limit(handlers.JobsHandler(options.Handlers, options.Jobs))},
+		{"/api/jobs/", limit(handlers.JobsHandler(options.Handlers, options.Jobs))},
+		{"/api/admin/usage", limit(handlers.UsageHandler(options.Handlers))},
+		{"/api/openapi.json", http.HandlerFunc(OpenAPIHandler)},
+		{"/metrics", metrics.Handler(metrics.Default)},
+	}
+
+	if options.Frontend != nil {
+		list = append(list, route{"/", web.Handler(options.Frontend)})
+	}
+	return list
+}
+
+// NewRouter registers every endpoint and wraps them all in the same
+// middleware chain.
+func NewRouter(options Options) http.Handler {
+	mux := http.NewServeMux()
+
+	for _, r := range routes(options) {
+		mux.Handle(r.pattern, r.handler)
+	}
+
+	route := func(r *http.Request) string {
+		_, pattern := mux.Handler(r)
+		return pattern
+	}
+
+	return middleware.Chain(mux,
+		middleware.RequestID(),
+		middleware.Logger(),
+		middleware.Metrics(route),
+		middleware.Recover(),
+		middleware.CORS(options.AllowedOrigins),
+		middleware.MaxBodySize(options.MaxBodyBytes),
+	)
+}
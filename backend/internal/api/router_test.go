@@ -0,0 +1,112 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/api/handlers"
+)
+
+func TestRouterAppliesMiddleware(t *testing.T) {
+	router := NewRouter(Options{
+		Handlers:       handlers.Config{MaxIterations: 1_000, MaxConcurrent: 2, MaxJobIterations: 1_000},
+		AllowedOrigins: []string{"http://localhost:5173"},
+		MaxBodyBytes:   64,
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/api/health", nil)
+	r.Header.Set("Origin", "http://localhost:5173")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "http://localhost:5173" {
+		t.Errorf("health: status %d, origin %q", w.Code, w.Header().Get("Access-Control-Allow-Origin"))
+	}
+	if w.Header().Get("X-Request-ID") == "" {
+		t.Errorf("health: missing request ID")
+	}
+
+	// Every route gets its CORS headers from the shared middleware, not
+	// from the handler.
+	for _, path := range []string{"/api/stats", "/api/replay", "/api/simulation/batch", "/api/jobs/missing"} {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		r.Header.Set("Origin", "http://localhost:5173")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+
+		if got := w.Header().Values("Access-Control-Allow-Origin"); len(got) != 1 || got[0] != "http://localhost:5173" {
+			t.Errorf("%s: Access-Control-Allow-Origin = %q", path, got)
+		}
+	}
+
+	r = httptest.NewRequest(http.MethodOptions, "/api/replay", nil)
+	r.Header.Set("Origin", "http://localhost:5173")
+	r.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("preflight: status = %d", w.Code)
+	}
+
+	body := `{"playerCards": [{"Rank":14,"Suit":0},{"Rank":14,"Suit":1}], "communityCards": [` + strings.Repeat(`{"Rank":2,"Suit":0},`, 10) + `]}`
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/simulation", strings.NewReader(body)))
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("large body: status = %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	for _, want := range []string{
+		`http_requests_total{route="/api/health",method="GET",status="200"}`,
+		`http_requests_total{route="/api/simulation",method="POST",status="413"}`,
+		"simulation_active_workers",
+		"jobs_queued",
+		"replay_equity_cache_hit_ratio",
+	} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Errorf("metrics: missing %s", want)
+		}
+	}
+}
+
+func TestRouterServesFrontend(t *testing.T) {
+	router := NewRouter(Options{
+		Handlers:     handlers.Config{MaxIterations: 1_000, MaxConcurrent: 2, MaxJobIterations: 1_000},
+		MaxBodyBytes: 1 << 10,
+		Frontend: fstest.MapFS{
+			"index.html":   {Data: []byte("<html>app</html>")},
+			"cards/AS.svg": {Data: []byte("<svg/>")},
+		},
+	})
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/", http.StatusOK, "<html>app</html>"},
+		{"/cards/AS.svg", http.StatusOK, "<svg/>"},
+		{"/some/client/route", http.StatusOK, "<html>app</html>"},
+		{"/api/health", http.StatusOK, `{"status":"ok"}`},
+		{"/api/missing", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if w.Code != tt.status {
+			t.Errorf("GET %s: status = %d, want %d", tt.path, w.Code, tt.status)
+		}
+		if tt.body != "" && strings.TrimSpace(w.Body.String()) != tt.body {
+			t.Errorf("GET %s: body = %q, want %q", tt.path, w.Body.String(), tt.body)
+		}
+	}
+}
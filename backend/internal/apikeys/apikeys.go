@@ -0,0 +1,375 @@
+package apikeys
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrQuotaExceeded = errors.New("daily iteration quota exceeded")
+	ErrInvalidKeys   = errors.New("invalid API keys file")
+)
+
+// Key is one entry of the keys file. IterationsPerDay is the number of
+// simulation iterations the key may request per UTC day, 0 for no limit.
+type Key struct {
+	Name             string `json:"name"`
+	Key              string `json:"key"`
+	IterationsPerDay int64  `json:"iterationsPerDay,omitempty"`
+	Admin            bool   `json:"admin,omitempty"`
+}
+
+// Usage is what one key has used, in total and on its current day.
+type Usage struct {
+	Name             string     `json:"name"`
+	Requests         int64      `json:"requests"`
+	Iterations       int64      `json:"iterations"`
+	Rejected         int64      `json:"rejected"`
+	Day              string     `json:"day,omitempty"`
+	DayIterations    int64      `json:"dayIterations"`
+	IterationsPerDay int64      `json:"iterationsPerDay,omitempty"`
+	LastUsed         *time.Time `json:"lastUsed,omitempty"`
+}
+
+// LoadKeys reads a JSON file of the form {"keys": [...]}. Every key needs a
+// unique name and secret.
+func LoadKeys(path string) ([]Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKeys, err)
+	}
+
+	var file struct {
+		Keys []Key `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidKeys, path, err)
+	}
+
+	names := make(map[string]bool)
+	secrets := make(map[string]bool)
+	for _, key := range file.Keys {
+		switch {
+		case key.Name == "" || key.Key == "":
+			return nil, fmt.Errorf("%w: %s: every key needs a name and a key", ErrInvalidKeys, path)
+		case names[key.Name]:
+			return nil, fmt.Errorf("%w: %s: name %q is used twice", ErrInvalidKeys, path, key.Name)
+		case secrets[key.Key]:
+			return nil, fmt.Errorf("%w: %s: key of %q is used twice", ErrInvalidKeys, path, key.Name)
+		case key.IterationsPerDay < 0:
+			return nil, fmt.Errorf("%w: %s: quota of %q is negative", ErrInvalidKeys, path, key.Name)
+		}
+		names[key.Name] = true
+		secrets[key.Key] = true
+	}
+
+	return file.Keys, nil
+}
+
+// flushInterval is how often changed usage is written to the usage file.
+const flushInterval = 30 * time.Second
+
+// Store checks keys and keeps their usage, saving it to usagePath so that
+// counters and quotas survive restarts.
+type Store struct {
+	keys      map[[32]byte]Key
+	usagePath string
+
+	mu    sync.Mutex
+	usage map[string]*Usage
+	dirty bool
+
+	wg   sync.WaitGroup
+	stop chan struct{}
+	once sync.Once
+}
+
+// NewStore loads any usage saved earlier to usagePath and starts saving it
+// in the background. An empty usagePath keeps usage in memory only.
+func NewStore(keys []Key, usagePath string) (*Store, error) {
+	s := &Store{
+		keys:      make(map[[32]byte]Key, len(keys)),
+		usagePath: usagePath,
+		usage:     make(map[string]*Usage),
+		stop:      make(chan struct{}),
+	}
+
+	for _, key := range keys {
+		s.keys[sha256.Sum256([]byte(key.Key))] = key
+	}
+
+	if usagePath != "" {
+		data, err := os.ReadFile(usagePath)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return nil, err
+		default:
+			if err := json.Unmarshal(data, &s.usage); err != nil {
+				return nil, fmt.Errorf("%s: %w", usagePath, err)
+			}
+		}
+	}
+
+	s.wg.Add(1)
+	go s.flusher()
+
+	return s, nil
+}
+
+// Authenticate returns the key with the given secret. Secrets are looked up
+// by their hash, so the lookup does not depend on how much of a secret
+// matches.
+func (s *Store) Authenticate(secret string) (Key, bool) {
+	if s == nil || secret == "" {
+		return Key{}, false
+	}
+	key, ok := s.keys[sha256.Sum256([]byte(secret))]
+	return key, ok
+}
+
+// Request counts one request made with the key.
+func (s *Store) Request(key Key, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	usage := s.usageFor(key, now)
+	usage.Requests++
+	usage.LastUsed = &now
+	s.dirty = true
+}
+
+// Charge takes iterations from the key's quota for today, or returns
+// ErrQuotaExceeded without taking anything if there are not enough left.
+func (s *Store) Charge(key Key, iterations int, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	usage := s.usageFor(key, now)
+	s.dirty = true
+
+	if key.IterationsPerDay > 0 && usage.DayIterations+int64(iterations) > key.IterationsPerDay {
+		usage.Rejected++
+		return ErrQuotaExceeded
+	}
+
+	usage.Iterations += int64(iterations)
+	usage.DayIterations += int64(iterations)
+	return nil
+}
+
+// usageFor returns the key's counters, starting a new day if the last one
+// is over.
+func (s *Store) usageFor(key Key, now time.Time) *Usage {
+	usage, ok := s.usage[key.Name]
+	if !ok {
+		usage = &Usage{Name: key.Name}
+		s.usage[key.Name] = usage
+	}
+
+	if day := now.UTC().Format(time.DateOnly); usage.Day != day {
+		usage.Day = day
+		usage.DayIterations = 0
+	}
+	return usage
+}
+
+// Usage lists every key, sorted by name, with its quota and what it has
+// used.
+func (s *Store) Usage() []Usage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]Usage, 0, len(s.keys))
+	for _, key := range s.keys {
+		usage := Usage{Name: key.Name}
+		if saved, ok := s.usage[key.Name]; ok {
+			usage = *saved
+		}
+		usage.IterationsPerDay = key.IterationsPerDay
+		list = append(list, usage)
+	}
+
+	slices.SortFunc(list, func(a, b Usage) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return list
+}
+
+// Save writes the usage file if anything changed since the last save. The
+// file is replaced in one step, so a crash never leaves half of it.
+func (s *Store) Save() error {
+	s.mu.Lock()
+	if s.usagePath == "" || !s.dirty {
+		s.mu.Unlock()
+		return nil
+	}
+	data, err := json.MarshalIndent(s.usage, "", "  ")
+	s.dirty = false
+	s.mu.Unlock()
+
+	if err == nil {
+		err = s.write(data)
+	}
+	if err != nil {
+		s.mu.Lock()
+		s.dirty = true
+		s.mu.Unlock()
+	}
+	return err
+}
+
+func (s *Store) write(data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(s.usagePath), filepath.Base(s.usagePath)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.usagePath)
+}
+
+func (s *Store) flusher() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			if err := s.Save(); err != nil {
+				slog.Error("saving API key usage failed", "error", err)
+			}
+		}
+	}
+}
+
+// Close stops the background saving and saves one last time.
+func (s *Store) Close() error {
+	if s == nil {
+		return nil
+	}
+	s.once.Do(func() { close(s.stop) })
+	s.wg.Wait()
+	return s.Save()
+}
+
+type keyContext struct{}
+
+func WithKey(ctx context.Context, key Key) context.Context {
+	return context.WithValue(ctx, keyContext{}, key)
+}
+
+// FromContext returns the key the request was authenticated with, if any.
+func FromContext(ctx context.Context) (Key, bool) {
+	key, ok := ctx.Value(keyContext{}).(Key)
+	return key, ok
+}
+
+// ChargeContext charges the key the request in ctx was made with. Requests
+// without a key, or a nil store, are not charged.
+func (s *Store) ChargeContext(ctx context.Context, iterations int) error {
+	key, ok := FromContext(ctx)
+	if s == nil || !ok {
+		return nil
+	}
+	return s.Charge(key, iterations, time.Now())
+}
+
+// RefundContext gives back iterations charged with ChargeContext for work
+// that never ran.
+func (s *Store) RefundContext(ctx context.Context, iterations int) {
+	key, ok := FromContext(ctx)
+	if s == nil || !ok {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	usage := s.usageFor(key, time.Now())
+	usage.Iterations -= int64(iterations)
+	usage.DayIterations = max(usage.DayIterations-int64(iterations), 0)
+	s.dirty = true
+}
+
+// Secret returns the key a client sent, either as apiKey or as a bearer
+// token in authorization. apiKey wins when both are set.
+func Secret(apiKey string, authorization string) string {
+	if apiKey != "" {
+		return apiKey
+	}
+	if bearer, ok := strings.CutPrefix(authorization, "Bearer "); ok {
+		return strings.TrimSpace(bearer)
+	}
+	return ""
+}
+
+// AuthenticateContext checks secret, counts the request and returns ctx
+// with the key in it. It reports false for an unknown key, and for a
+// missing one unless allowAnonymous is set. A nil store lets every request
+// through without a key.
+func (s *Store) AuthenticateContext(ctx context.Context, secret string, allowAnonymous bool) (context.Context, bool) {
+	if s == nil || (secret == "" && allowAnonymous) {
+		return ctx, true
+	}
+
+	key, ok := s.Authenticate(secret)
+	if !ok {
+		return ctx, false
+	}
+
+	s.Request(key, time.Now())
+	return WithKey(ctx, key), true
+}
+
+// ClientKey names the client behind a request for rate limiting: by the
+// API key in ctx, or else by its IP address. Behind a trusted proxy that is
+// the last address in forwardedFor, the one the proxy added itself; earlier
+// ones are whatever the client sent. remoteAddr is the connection's address
+// as host:port.
+func ClientKey(ctx context.Context, remoteAddr string, forwardedFor []string, trustProxy bool) string {
+	if key, ok := FromContext(ctx); ok {
+		return "key:" + key.Name
+	}
+
+	if trustProxy && len(forwardedFor) > 0 {
+		addrs := strings.Split(forwardedFor[len(forwardedFor)-1], ",")
+		if addr := strings.TrimSpace(addrs[len(addrs)-1]); addr != "" {
+			return "ip:" + addr
+		}
+	}
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	return "ip:" + host
+}
+
+// QuotaResets is when the daily quotas start over after now.
+func QuotaResets(now time.Time) time.Time {
+	year, month, day := now.UTC().Date()
+	return time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,169 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "keys.json")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    int
+		wantErr bool
+	}{
+		{"Valid", `{"keys": [{"name": "a", "key": "s1", "iterationsPerDay": 100}, {"name": "b", "key": "s2", "admin": true}]}`, 2, false},
+		{"Not JSON", `keys`, 0, true},
+		{"Missing key", `{"keys": [{"name": "a"}]}`, 0, true},
+		{"Duplicate name", `{"keys": [{"name": "a", "key": "s1"}, {"name": "a", "key": "s2"}]}`, 0, true},
+		{"Duplicate key", `{"keys": [{"name": "a", "key": "s1"}, {"name": "b", "key": "s1"}]}`, 0, true},
+		{"Negative quota", `{"keys": [{"name": "a", "key": "s1", "iterationsPerDay": -1}]}`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := LoadKeys(writeFile(t, tt.content))
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidKeys) {
+					t.Errorf("LoadKeys() error = %v, want %v", err, ErrInvalidKeys)
+				}
+				return
+			}
+			if err != nil || len(keys) != tt.want {
+				t.Errorf("LoadKeys() = %d keys, %v, want %d", len(keys), err, tt.want)
+			}
+		})
+	}
+}
+
+func TestStore(t *testing.T) {
+	usagePath := filepath.Join(t.TempDir(), "usage.json")
+	keys := []Key{{Name: "partner", Key: "secret", IterationsPerDay: 1_000}, {Name: "admin", Key: "root", Admin: true}}
+
+	store, err := NewStore(keys, usagePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := store.Authenticate("wrong"); ok {
+		t.Errorf("Authenticate() accepted an unknown key")
+	}
+	partner, ok := store.Authenticate("secret")
+	if !ok || partner.Name != "partner" {
+		t.Fatalf("Authenticate() = %+v, %v", partner, ok)
+	}
+
+	day := time.Date(2026, 10, 19, 23, 0, 0, 0, time.UTC)
+
+	store.Request(partner, day)
+	if err := store.Charge(partner, 600, day); err != nil {
+		t.Errorf("Charge(600) error = %v", err)
+	}
+	if err := store.Charge(partner, 600, day); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("Charge(600) over quota error = %v, want %v", err, ErrQuotaExceeded)
+	}
+	if err := store.Charge(partner, 600, day.Add(2*time.Hour)); err != nil {
+		t.Errorf("Charge(600) on the next day error = %v", err)
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	reloaded, err := NewStore(keys, usagePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reloaded.Close()
+
+	usage := reloaded.Usage()
+	if len(usage) != 2 || usage[0].Name != "admin" || usage[1].Name != "partner" {
+		t.Fatalf("Usage() = %+v", usage)
+	}
+
+	got := usage[1]
+	if got.Requests != 1 || got.Iterations != 1_200 || got.Rejected != 1 ||
+		got.Day != "2026-10-20" || got.DayIterations != 600 || got.IterationsPerDay != 1_000 {
+		t.Errorf("partner usage after reload = %+v", got)
+	}
+}
+
+func TestChargeContext(t *testing.T) {
+	store, _ := NewStore([]Key{{Name: "partner", Key: "secret", IterationsPerDay: 100}}, "")
+	defer store.Close()
+
+	if err := store.ChargeContext(context.Background(), 1_000); err != nil {
+		t.Errorf("anonymous request was charged: %v", err)
+	}
+
+	key, _ := store.Authenticate("secret")
+	ctx := WithKey(context.Background(), key)
+
+	if err := store.ChargeContext(ctx, 100); err != nil {
+		t.Errorf("ChargeContext(100) error = %v", err)
+	}
+	store.RefundContext(ctx, 100)
+	if err := store.ChargeContext(ctx, 100); err != nil {
+		t.Errorf("ChargeContext(100) after a refund error = %v", err)
+	}
+
+	var nilStore *Store
+	if err := nilStore.ChargeContext(ctx, 1_000); err != nil {
+		t.Errorf("nil store charged: %v", err)
+	}
+}
+
+func TestAuthenticateContext(t *testing.T) {
+	store, _ := NewStore([]Key{{Name: "partner", Key: "secret"}}, "")
+	defer store.Close()
+
+	tests := []struct {
+		name           string
+		store          *Store
+		apiKey         string
+		authorization  string
+		allowAnonymous bool
+		wantOK         bool
+		wantClient     string
+	}{
+		{"Key", store, "secret", "", false, true, "key:partner"},
+		{"Bearer token", store, "", "Bearer secret", false, true, "key:partner"},
+		{"Key wins over bearer token", store, "secret", "Bearer guess", false, true, "key:partner"},
+		{"Unknown key", store, "guess", "", true, false, ""},
+		{"Anonymous allowed", store, "", "", true, true, "ip:192.0.2.1"},
+		{"Anonymous refused", store, "", "Basic c2VjcmV0", false, false, ""},
+		{"No store", nil, "guess", "", false, true, "ip:192.0.2.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, ok := tt.store.AuthenticateContext(context.Background(), Secret(tt.apiKey, tt.authorization), tt.allowAnonymous)
+			if ok != tt.wantOK {
+				t.Fatalf("AuthenticateContext() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if got := ClientKey(ctx, "192.0.2.1:1234", nil, false); ok && got != tt.wantClient {
+				t.Errorf("ClientKey() = %q, want %q", got, tt.wantClient)
+			}
+		})
+	}
+}
+
+func TestQuotaResets(t *testing.T) {
+	now := time.Date(2026, 12, 31, 18, 30, 0, 0, time.UTC)
+	if got := QuotaResets(now); !got.Equal(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("QuotaResets() = %v", got)
+	}
+}
@@ -0,0 +1,116 @@
+package bot
+
+import (
+	"math/rand"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/game"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+	simulator "github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/simulation"
+)
+
+type Player interface {
+	Act(view game.View) game.Action
+}
+
+func passive(view game.View) game.Action {
+	if _, ok := view.Legal(game.Check); ok {
+		return game.Action{Seat: view.Seat, Type: game.Check}
+	}
+	return game.Action{Seat: view.Seat, Type: game.Fold}
+}
+
+func aggressive(view game.View, amount int) (game.Action, bool) {
+	for _, actionType := range []game.ActionType{game.Bet, game.Raise} {
+		if legal, ok := view.Legal(actionType); ok {
+			amount = max(legal.MinAmount, min(amount, legal.MaxAmount))
+			return game.Action{Seat: view.Seat, Type: actionType, Amount: amount}, true
+		}
+	}
+	return game.Action{}, false
+}
+
+type RandomBot struct {
+	rng *rand.Rand
+}
+
+func NewRandomBot(seed int64) *RandomBot {
+	return &RandomBot{rng: rand.New(rand.NewSource(seed))}
+}
+
+func (b *RandomBot) Act(view game.View) game.Action {
+	legal := view.LegalActions[b.rng.Intn(len(view.LegalActions))]
+	action := game.Action{Seat: view.Seat, Type: legal.Type}
+
+	if legal.Type == game.Bet || legal.Type == game.Raise {
+		action.Amount = legal.MinAmount + b.rng.Intn(legal.MaxAmount-legal.MinAmount+1)
+	}
+
+	return action
+}
+
+type CallingStation struct{}
+
+func (CallingStation) Act(view game.View) game.Action {
+	if _, ok := view.Legal(game.Call); ok {
+		return game.Action{Seat: view.Seat, Type: game.Call}
+	}
+	return passive(view)
+}
+
+// EquityBot estimates its equity against random hands for every live
+// opponent. It raises the pot above RaiseThreshold, calls whenever its
+// equity beats the pot odds and otherwise checks or folds.
+type EquityBot struct {
+	RaiseThreshold float64
+	Iterations     int
+}
+
+func NewEquityBot(raiseThreshold float64, iterations int) *EquityBot {
+	return &EquityBot{RaiseThreshold: raiseThreshold, Iterations: iterations}
+}
+
+func (b *EquityBot) Act(view game.View) game.Action {
+	equity := b.equity(view)
+
+	if equity >= b.RaiseThreshold {
+		if action, ok := aggressive(view, view.CurrentBet+view.Pot); ok {
+			return action
+		}
+	}
+
+	if toCall := view.ToCall(); toCall > 0 {
+		potOdds := float64(toCall) / float64(view.Pot+toCall)
+		if equity >= potOdds {
+			return game.Action{Seat: view.Seat, Type: game.Call}
+		}
+	}
+
+	return passive(view)
+}
+
+func (b *EquityBot) equity(view game.View) float64 {
+	hands := []poker.Hand{poker.NewHand(view.HoleCards...)}
+	contributions := []int{1}
+
+	for seat, other := range view.Seats {
+		if seat != view.Seat && other.InHand && !other.Folded {
+			hands = append(hands, poker.NewHand())
+			contributions = append(contributions, 1)
+		}
+	}
+
+	sim := simulator.NewAllInSimulator(simulator.AllInConfig{
+		Hands:          hands,
+		Contributions:  contributions,
+		CommunityCards: view.Board,
+		NumIterations:  b.Iterations,
+		NumConcurrent:  1,
+	})
+
+	result, err := sim.RunSimulation()
+	if err != nil {
+		return 0
+	}
+
+	return result.Equity[0]
+}
@@ -0,0 +1,132 @@
+package bot
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/game"
+)
+
+func TestBotsOnlyTakeLegalActions(t *testing.T) {
+	players := []Player{NewRandomBot(1), CallingStation{}, NewEquityBot(0.6, 50)}
+
+	for hand := 0; hand < 30; hand++ {
+		g, err := game.NewGame(game.Config{SmallBlind: 1, BigBlind: 2, Button: hand % 3, Seed: int64(hand)},
+			[]game.Seat{{Name: "random", Stack: 200}, {Name: "station", Stack: 200}, {Name: "equity", Stack: 200}})
+		if err != nil {
+			t.Fatalf("NewGame() error = %v", err)
+		}
+
+		for !g.Done() {
+			seat := g.ToAct()
+			action := players[seat].Act(g.View(seat))
+			if err := g.Apply(action); err != nil {
+				t.Fatalf("seat %d took illegal action %+v: %v", seat, action, err)
+			}
+		}
+	}
+}
+
+func TestRunIsZeroSum(t *testing.T) {
+	standings, err := Run(RunConfig{
+		Entrants: []Entrant{
+			{Name: "random", New: func(seed int64) Player { return NewRandomBot(seed) }},
+			{Name: "station", New: func(int64) Player { return CallingStation{} }},
+		},
+		NumHands:      2000,
+		SmallBlind:    1,
+		BigBlind:      2,
+		NumConcurrent: 4,
+		Seed:          3,
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if standings[0].Hands != 2000 {
+		t.Errorf("Hands = %d, want 2000", standings[0].Hands)
+	}
+	if standings[0].NetChips+standings[1].NetChips != 0 {
+		t.Errorf("net chips should sum to zero, got %d and %d", standings[0].NetChips, standings[1].NetChips)
+	}
+	if standings[0].CI95 <= 0 {
+		t.Errorf("CI95 = %v, want positive", standings[0].CI95)
+	}
+}
+
+func TestRunDoesNotDependOnWorkers(t *testing.T) {
+	config := RunConfig{
+		Entrants: []Entrant{
+			{Name: "random", New: func(seed int64) Player { return NewRandomBot(seed) }},
+			{Name: "station", New: func(int64) Player { return CallingStation{} }},
+		},
+		NumHands:   500,
+		SmallBlind: 1,
+		BigBlind:   2,
+		Seed:       7,
+	}
+
+	var net []int
+	for _, workers := range []int{1, 3, 8} {
+		config.NumConcurrent = workers
+		standings, err := Run(config)
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+
+		if net == nil {
+			net = []int{standings[0].NetChips, standings[1].NetChips}
+		} else if standings[0].NetChips != net[0] || standings[1].NetChips != net[1] {
+			t.Errorf("%d workers: net chips = %d and %d, want %d and %d", workers, standings[0].NetChips, standings[1].NetChips, net[0], net[1])
+		}
+	}
+}
+
+func TestRunSeedsBotsApartFromDecks(t *testing.T) {
+	var mu sync.Mutex
+	seeds := make(map[int64]bool)
+	record := func(seed int64) Player {
+		mu.Lock()
+		defer mu.Unlock()
+		seeds[seed] = true
+		return CallingStation{}
+	}
+
+	config := RunConfig{
+		Entrants:      []Entrant{{Name: "first", New: record}, {Name: "second", New: record}},
+		NumHands:      200,
+		SmallBlind:    1,
+		BigBlind:      2,
+		NumConcurrent: 4,
+		Seed:          7,
+	}
+	if _, err := Run(config); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if len(seeds) != config.NumHands*len(config.Entrants) {
+		t.Errorf("%d distinct bot seeds, want %d", len(seeds), config.NumHands*len(config.Entrants))
+	}
+	for hand := 0; hand < config.NumHands; hand++ {
+		if seeds[config.Seed+int64(hand)] {
+			t.Errorf("a bot shares seed %d with the deck of hand %d", config.Seed+int64(hand), hand)
+		}
+	}
+}
+
+func TestRunReportsFailedHands(t *testing.T) {
+	_, err := Run(RunConfig{
+		Entrants: []Entrant{
+			{Name: "station 1", New: func(int64) Player { return CallingStation{} }},
+			{Name: "station 2", New: func(int64) Player { return CallingStation{} }},
+		},
+		NumHands:      10,
+		SmallBlind:    -1,
+		BigBlind:      2,
+		NumConcurrent: 2,
+	})
+	if !errors.Is(err, game.ErrInvalidTable) {
+		t.Errorf("Run() error = %v, want %v", err, game.ErrInvalidTable)
+	}
+}
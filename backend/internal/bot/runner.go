@@ -0,0 +1,187 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/game"
+)
+
+type Entrant struct {
+	Name string
+	New  func(seed int64) Player
+}
+
+type RunConfig struct {
+	Entrants      []Entrant
+	NumHands      int
+	SmallBlind    int
+	BigBlind      int
+	StackInBlinds int
+	NumConcurrent int
+	Seed          int64
+}
+
+type Standing struct {
+	Name     string
+	Hands    int
+	NetChips int
+	BBPer100 float64
+	CI95     float64
+}
+
+type handResult struct {
+	net []int
+	err error
+}
+
+// Run plays NumHands hands between the entrants, who keep their seats while
+// the button rotates. Every hand starts from fresh stacks and seeds the deck
+// from the hand number and the entrants from a separate stream drawn up
+// front, so with bots that only draw from their seed the results do not
+// depend on how hands are split between workers. The first hand that cannot
+// be played stops the run.
+func Run(config RunConfig) ([]Standing, error) {
+	if len(config.Entrants) < 2 || len(config.Entrants) > 10 {
+		return nil, errors.New("between 2 and 10 entrants are required")
+	}
+	if config.NumHands <= 0 || config.NumConcurrent <= 0 {
+		return nil, errors.New("hands and concurrency must be positive")
+	}
+	if config.BigBlind <= 0 {
+		return nil, errors.New("big blind must be positive")
+	}
+	if config.StackInBlinds <= 0 {
+		config.StackInBlinds = 100
+	}
+
+	seeds := botSeeds(config.Seed, config.NumHands*len(config.Entrants))
+	results := make(chan handResult, config.NumConcurrent)
+
+	var wg sync.WaitGroup
+
+	for worker := 0; worker < config.NumConcurrent; worker++ {
+		wg.Add(1)
+		go runWorker(config, seeds, worker, results, &wg)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	hands := 0
+	sums := make([]float64, len(config.Entrants))
+	squares := make([]float64, len(config.Entrants))
+	net := make([]int, len(config.Entrants))
+	var err error
+
+	for result := range results {
+		if result.err != nil {
+			if err == nil {
+				err = result.err
+			}
+			continue
+		}
+
+		hands++
+		for seat, chips := range result.net {
+			blinds := float64(chips) / float64(config.BigBlind)
+			sums[seat] += blinds
+			squares[seat] += blinds * blinds
+			net[seat] += chips
+		}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	standings := make([]Standing, len(config.Entrants))
+	for seat, entrant := range config.Entrants {
+		mean := sums[seat] / float64(hands)
+		variance := squares[seat]/float64(hands) - mean*mean
+
+		standings[seat] = Standing{
+			Name:     entrant.Name,
+			Hands:    hands,
+			NetChips: net[seat],
+			BBPer100: mean * 100,
+			CI95:     1.96 * math.Sqrt(max(variance, 0)/float64(hands)) * 100,
+		}
+	}
+
+	return standings, nil
+}
+
+// botSeeds draws n seeds for the entrants from their own stream, so that the
+// bots do not replay the shuffles of the decks seeded from the hand number.
+func botSeeds(seed int64, n int) []int64 {
+	rng := rand.New(rand.NewSource(seed))
+
+	seeds := make([]int64, n)
+	for i := range seeds {
+		seeds[i] = rng.Int63()
+	}
+	return seeds
+}
+
+func runWorker(config RunConfig, seeds []int64, worker int, results chan<- handResult, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	players := make([]Player, len(config.Entrants))
+
+	for hand := worker; hand < config.NumHands; hand += config.NumConcurrent {
+		for seat, entrant := range config.Entrants {
+			players[seat] = entrant.New(seeds[hand*len(config.Entrants)+seat])
+		}
+
+		net, err := playHand(config, players, hand)
+		if err != nil {
+			results <- handResult{err: fmt.Errorf("hand %d: %w", hand, err)}
+			return
+		}
+		results <- handResult{net: net}
+	}
+}
+
+func playHand(config RunConfig, players []Player, hand int) ([]int, error) {
+	stack := config.StackInBlinds * config.BigBlind
+
+	seats := make([]game.Seat, len(config.Entrants))
+	for seat, entrant := range config.Entrants {
+		seats[seat] = game.Seat{Name: entrant.Name, Stack: stack}
+	}
+
+	g, err := game.NewGame(game.Config{
+		SmallBlind: config.SmallBlind,
+		BigBlind:   config.BigBlind,
+		Button:     hand % len(seats),
+		Seed:       config.Seed + int64(hand),
+	}, seats)
+	if err != nil {
+		return nil, err
+	}
+
+	for !g.Done() {
+		seat := g.ToAct()
+		view := g.View(seat)
+
+		if err := g.Apply(players[seat].Act(view)); err != nil {
+			// A bot that asks for an illegal action gives up its turn.
+			if err := g.Apply(passive(view)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	net := make([]int, len(seats))
+	for seat, player := range g.Players() {
+		net[seat] = player.Stack - stack
+	}
+
+	return net, nil
+}
@@ -0,0 +1,371 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	ListenAddr       string
+	GRPCListenAddr   string
+	AllowedOrigins   []string
+	MaxIterations    int
+	MaxConcurrent    int
+	MaxJobIterations int
+	JobWorkers       int
+	JobQueueSize     int
+	JobTTL           time.Duration
+	ReadTimeout      time.Duration
+	WriteTimeout     time.Duration
+	IdleTimeout      time.Duration
+	ShutdownTimeout  time.Duration
+	DrainDelay       time.Duration
+	MaxBodyBytes     int64
+	RateLimit        float64
+	RateBurst        int
+	MaxWorkers       int
+	TrustProxy       bool
+	APIKeysFile      string
+	UsageFile        string
+	AllowAnonymous   bool
+	LogLevel         string
+	RedactCards      bool
+	HandHistoryDir   string
+	FrontendDir      string
+}
+
+var ErrInvalid = errors.New("invalid configuration")
+
+func Default() Config {
+	return Config{
+		ListenAddr: ":8080",
+		AllowedOrigins: []string{
+			"http://localhost:5173",
+			"http://localhost:4173",
+			"https://texas-holdem-calculator.onrender.com",
+		},
+		MaxIterations:    10_000,
+		MaxConcurrent:    16,
+		MaxJobIterations: 50_000_000,
+		JobWorkers:       2,
+		JobQueueSize:     32,
+		JobTTL:           time.Hour,
+		ReadTimeout:      10 * time.Second,
+		WriteTimeout:     60 * time.Second,
+		IdleTimeout:      2 * time.Minute,
+		ShutdownTimeout:  30 * time.Second,
+		MaxBodyBytes:     1 << 20,
+		RateLimit:        5,
+		RateBurst:        20,
+		MaxWorkers:       64,
+		UsageFile:        "usage.json",
+		AllowAnonymous:   true,
+		LogLevel:         "info",
+		RedactCards:      true,
+	}
+}
+
+// setting is one option, known by the same name as a flag and as a key in
+// the config file, and by env as an environment variable.
+type setting struct {
+	name  string
+	env   string
+	usage string
+	set   func(c *Config, value string) error
+}
+
+var settings = []setting{
+	{"listen", "LISTEN_ADDR", "address to listen on", func(c *Config, v string) error {
+		c.ListenAddr = v
+		return nil
+	}},
+	{"port", "PORT", "port to listen on on all interfaces, overrides the port of -listen", func(c *Config, v string) error {
+		if _, err := strconv.Atoi(v); err != nil {
+			return err
+		}
+		host, _, _ := net.SplitHostPort(c.ListenAddr)
+		c.ListenAddr = net.JoinHostPort(host, v)
+		return nil
+	}},
+	{"grpc-listen", "GRPC_LISTEN_ADDR", "address to serve the gRPC API on, empty to not serve it", func(c *Config, v string) error {
+		c.GRPCListenAddr = v
+		return nil
+	}},
+	{"allowed-origins", "ALLOWED_ORIGINS", "comma-separated origins allowed by CORS", func(c *Config, v string) error {
+		c.AllowedOrigins = nil
+		for _, origin := range strings.Split(v, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				c.AllowedOrigins = append(c.AllowedOrigins, origin)
+			}
+		}
+		return nil
+	}},
+	{"max-iterations", "MAX_ITERATIONS", "iteration cap for synchronous simulations", intSetter(func(c *Config) *int { return &c.MaxIterations })},
+	{"max-concurrent", "MAX_CONCURRENT", "worker cap per simulation", intSetter(func(c *Config) *int { return &c.MaxConcurrent })},
+	{"max-job-iterations", "MAX_JOB_ITERATIONS", "iteration cap for simulation jobs", intSetter(func(c *Config) *int { return &c.MaxJobIterations })},
+	{"job-workers", "JOB_WORKERS", "jobs run at the same time", intSetter(func(c *Config) *int { return &c.JobWorkers })},
+	{"job-queue-size", "JOB_QUEUE_SIZE", "jobs that can wait in the queue", intSetter(func(c *Config) *int { return &c.JobQueueSize })},
+	{"job-ttl", "JOB_TTL", "how long finished jobs are kept", durationSetter(func(c *Config) *time.Duration { return &c.JobTTL })},
+	{"read-timeout", "READ_TIMEOUT", "HTTP read timeout", durationSetter(func(c *Config) *time.Duration { return &c.ReadTimeout })},
+	{"write-timeout", "WRITE_TIMEOUT", "HTTP write timeout", durationSetter(func(c *Config) *time.Duration { return &c.WriteTimeout })},
+	{"idle-timeout", "IDLE_TIMEOUT", "HTTP keep-alive idle timeout", durationSetter(func(c *Config) *time.Duration { return &c.IdleTimeout })},
+	{"shutdown-timeout", "SHUTDOWN_TIMEOUT", "how long in-flight requests get to finish at shutdown before they are canceled", durationSetter(func(c *Config) *time.Duration { return &c.ShutdownTimeout })},
+	{"drain-delay", "DRAIN_DELAY", "how long to keep serving while reporting not ready before shutting down", durationSetter(func(c *Config) *time.Duration { return &c.DrainDelay })},
+	{"max-body-bytes", "MAX_BODY_BYTES", "largest request body accepted", func(c *Config, v string) error {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return err
+		}
+		c.MaxBodyBytes = n
+		return nil
+	}},
+	{"rate-limit", "RATE_LIMIT", "requests per second allowed per client, 0 for no limit", func(c *Config, v string) error {
+		rate, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return err
+		}
+		c.RateLimit = rate
+		return nil
+	}},
+	{"rate-burst", "RATE_BURST", "requests a client can make at once before the rate limit applies", intSetter(func(c *Config) *int { return &c.RateBurst })},
+	{"max-workers", "MAX_WORKERS", "simulation workers allowed to run at once across all requests", intSetter(func(c *Config) *int { return &c.MaxWorkers })},
+	{"trust-proxy", "TRUST_PROXY", "take the client address from X-Forwarded-For", func(c *Config, v string) error {
+		trust, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		c.TrustProxy = trust
+		return nil
+	}},
+	{"api-keys-file", "API_KEYS_FILE", "JSON file of API keys; keys are not checked without one", func(c *Config, v string) error {
+		c.APIKeysFile = v
+		return nil
+	}},
+	{"usage-file", "USAGE_FILE", "where API key usage is saved, empty to keep it in memory", func(c *Config, v string) error {
+		c.UsageFile = v
+		return nil
+	}},
+	{"allow-anonymous", "ALLOW_ANONYMOUS", "accept requests without an API key when keys are checked", func(c *Config, v string) error {
+		allow, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		c.AllowAnonymous = allow
+		return nil
+	}},
+	{"log-level", "LOG_LEVEL", "debug, info, warn or error", func(c *Config, v string) error {
+		c.LogLevel = strings.ToLower(v)
+		return nil
+	}},
+	{"redact-cards", "REDACT_CARDS", "leave card data out of the logs", func(c *Config, v string) error {
+		redact, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		c.RedactCards = redact
+		return nil
+	}},
+	{"hand-history-dir", "HAND_HISTORY_DIR", "directory of hand histories to load at startup", func(c *Config, v string) error {
+		c.HandHistoryDir = v
+		return nil
+	}},
+	{"frontend-dir", "FRONTEND_DIR", "directory of the built frontend to serve instead of the embedded one", func(c *Config, v string) error {
+		c.FrontendDir = v
+		return nil
+	}},
+}
+
+func intSetter(field func(c *Config) *int) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		*field(c) = n
+		return nil
+	}
+}
+
+func durationSetter(field func(c *Config) *time.Duration) func(c *Config, value string) error {
+	return func(c *Config, value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		*field(c) = d
+		return nil
+	}
+}
+
+// Load builds the configuration from, in increasing priority, the defaults,
+// the JSON config file named by -config or CONFIG_FILE, environment
+// variables and command-line flags, and validates the result.
+func Load(args []string, getenv func(string) string) (Config, error) {
+	flags := flag.NewFlagSet("server", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+
+	configFile := flags.String("config", getenv("CONFIG_FILE"), "JSON config file")
+	fromFlags := make(map[string]string)
+	var order []string
+	for _, s := range settings {
+		flags.Func(s.name, s.usage, func(value string) error {
+			fromFlags[s.name] = value
+			order = append(order, s.name)
+			return nil
+		})
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return Config{}, fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+
+	config := Default()
+
+	if *configFile != "" {
+		if err := config.applyFile(*configFile); err != nil {
+			return Config{}, err
+		}
+	}
+
+	for _, s := range settings {
+		if value := getenv(s.env); value != "" {
+			if err := s.set(&config, value); err != nil {
+				return Config{}, fmt.Errorf("%w: %s: %v", ErrInvalid, s.env, err)
+			}
+		}
+	}
+
+	for _, name := range order {
+		if err := lookup(name).set(&config, fromFlags[name]); err != nil {
+			return Config{}, fmt.Errorf("%w: -%s: %v", ErrInvalid, name, err)
+		}
+	}
+
+	if err := config.Validate(); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+func lookup(name string) setting {
+	for _, s := range settings {
+		if s.name == name {
+			return s
+		}
+	}
+	return setting{}
+}
+
+// applyFile reads a JSON object keyed by flag name. Values may be strings,
+// numbers or, for allowed-origins, a list.
+func (c *Config) applyFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrInvalid, path, err)
+	}
+
+	for _, s := range settings {
+		value, ok := values[s.name]
+		if !ok {
+			continue
+		}
+		delete(values, s.name)
+
+		var text string
+		switch v := value.(type) {
+		case string:
+			text = v
+		case float64:
+			text = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			text = strconv.FormatBool(v)
+		case []any:
+			parts := make([]string, len(v))
+			for i, part := range v {
+				parts[i] = fmt.Sprint(part)
+			}
+			text = strings.Join(parts, ",")
+		default:
+			return fmt.Errorf("%w: %s: unsupported value for %q", ErrInvalid, path, s.name)
+		}
+
+		if err := s.set(c, text); err != nil {
+			return fmt.Errorf("%w: %s: %s: %v", ErrInvalid, path, s.name, err)
+		}
+	}
+
+	for name := range values {
+		return fmt.Errorf("%w: %s: unknown setting %q", ErrInvalid, path, name)
+	}
+
+	return nil
+}
+
+func (c Config) Validate() error {
+	var problems []string
+
+	if _, port, err := net.SplitHostPort(c.ListenAddr); err != nil || port == "" {
+		problems = append(problems, "listen address needs a port")
+	}
+	if c.GRPCListenAddr != "" {
+		if _, port, err := net.SplitHostPort(c.GRPCListenAddr); err != nil || port == "" {
+			problems = append(problems, "gRPC listen address needs a port")
+		} else if c.GRPCListenAddr == c.ListenAddr {
+			problems = append(problems, "the gRPC and HTTP listen addresses must differ")
+		}
+	}
+	if c.MaxIterations <= 0 || c.MaxConcurrent <= 0 || c.MaxJobIterations <= 0 {
+		problems = append(problems, "iteration and concurrency limits must be positive")
+	}
+	if c.MaxWorkers < c.MaxConcurrent {
+		problems = append(problems, "max workers must be at least max concurrent")
+	}
+	if c.RateLimit < 0 || c.RateBurst <= 0 {
+		problems = append(problems, "the rate limit must not be negative and the burst must be positive")
+	}
+	if c.MaxBodyBytes <= 0 {
+		problems = append(problems, "the request body limit must be positive")
+	}
+	if c.JobWorkers <= 0 || c.JobQueueSize < 0 {
+		problems = append(problems, "job workers must be positive and the queue size not negative")
+	}
+	if c.JobTTL <= 0 || c.ReadTimeout <= 0 || c.WriteTimeout <= 0 || c.IdleTimeout <= 0 || c.ShutdownTimeout <= 0 {
+		problems = append(problems, "timeouts and the job TTL must be positive")
+	}
+	if c.DrainDelay < 0 {
+		problems = append(problems, "the drain delay must not be negative")
+	}
+	switch c.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		problems = append(problems, fmt.Sprintf("unknown log level %q", c.LogLevel))
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w: %s", ErrInvalid, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Usage describes every flag and its environment variable.
+func Usage() string {
+	var b strings.Builder
+	b.WriteString("  -config FILE (CONFIG_FILE)\n\tJSON config file keyed by flag name\n")
+	for _, s := range settings {
+		fmt.Fprintf(&b, "  -%s (%s)\n\t%s\n", s.name, s.env, s.usage)
+	}
+	return b.String()
+}
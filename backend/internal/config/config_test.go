@@ -0,0 +1,108 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func env(values map[string]string) func(string) string {
+	return func(key string) string {
+		return values[key]
+	}
+}
+
+func TestLoadDefaults(t *testing.T) {
+	config, err := Load(nil, env(nil))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(config, Default()) {
+		t.Errorf("Load() = %+v, want defaults", config)
+	}
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.json")
+	file := `{"listen": "127.0.0.1:9000", "allowed-origins": ["https://a.example", "https://b.example"], "max-iterations": 5000, "job-ttl": "10m", "log-level": "debug"}`
+	if err := os.WriteFile(path, []byte(file), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := Load(
+		[]string{"-config", path, "-max-iterations", "20000"},
+		env(map[string]string{"MAX_ITERATIONS": "7000", "PORT": "9100", "LOG_LEVEL": "WARN"}),
+	)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if config.ListenAddr != "127.0.0.1:9100" {
+		t.Errorf("ListenAddr = %q", config.ListenAddr)
+	}
+	if !reflect.DeepEqual(config.AllowedOrigins, []string{"https://a.example", "https://b.example"}) {
+		t.Errorf("AllowedOrigins = %v", config.AllowedOrigins)
+	}
+	if config.MaxIterations != 20000 {
+		t.Errorf("MaxIterations = %d, flags should win", config.MaxIterations)
+	}
+	if config.JobTTL != 10*time.Minute || config.LogLevel != "warn" {
+		t.Errorf("JobTTL = %v, LogLevel = %q", config.JobTTL, config.LogLevel)
+	}
+}
+
+func TestLoadFileBooleans(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.json")
+	file := `{"redact-cards": false, "allow-anonymous": false, "trust-proxy": true}`
+	if err := os.WriteFile(path, []byte(file), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := Load([]string{"-config", path}, env(nil))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if config.RedactCards || config.AllowAnonymous || !config.TrustProxy {
+		t.Errorf("RedactCards = %v, AllowAnonymous = %v, TrustProxy = %v", config.RedactCards, config.AllowAnonymous, config.TrustProxy)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.json")
+	os.WriteFile(path, []byte(`{"max-iteratons": 5}`), 0o644)
+
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+	}{
+		{"Bad number", []string{"-max-iterations", "lots"}, nil},
+		{"Bad duration", nil, map[string]string{"JOB_TTL": "forever"}},
+		{"Negative limit", []string{"-max-concurrent", "-1"}, nil},
+		{"Unknown log level", []string{"-log-level", "loud"}, nil},
+		{"Negative drain delay", []string{"-drain-delay", "-5s"}, nil},
+		{"Workers below max concurrent", nil, map[string]string{"MAX_WORKERS": "4"}},
+		{"Missing port", []string{"-listen", "localhost"}, nil},
+		{"Same gRPC address", []string{"-grpc-listen", ":8080"}, nil},
+		{"Missing gRPC port", nil, map[string]string{"GRPC_LISTEN_ADDR": "localhost"}},
+		{"Unknown file setting", []string{"-config", path}, nil},
+		{"Missing file", []string{"-config", filepath.Join(t.TempDir(), "missing.json")}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Load(tt.args, env(tt.env)); !errors.Is(err, ErrInvalid) {
+				t.Errorf("Load() error = %v, want %v", err, ErrInvalid)
+			}
+		})
+	}
+
+	if _, err := Load([]string{"-h"}, env(nil)); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("Load(-h) error = %v, want %v", err, flag.ErrHelp)
+	}
+}
@@ -0,0 +1,183 @@
+package ev
+
+import (
+	"math"
+	"slices"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/game"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/handhistory"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+	simulator "github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/simulation"
+)
+
+// Hand histories use fractional amounts; the simulator works in whole chips.
+const chipScale = 100
+
+type Options struct {
+	NumIterations int
+	NumConcurrent int
+}
+
+type Point struct {
+	HandID             string
+	AllIn              bool
+	Street             game.Street
+	Equity             float64
+	Actual             float64
+	Adjusted           float64
+	CumulativeActual   float64
+	CumulativeAdjusted float64
+}
+
+type Report struct {
+	Player     string
+	Hands      int
+	AllInHands int
+	Actual     float64
+	Adjusted   float64
+	Points     []Point
+}
+
+type AllIn struct {
+	Street    game.Street
+	Players   []string
+	Equity    map[string]float64
+	Expected  map[string]float64
+	Contested float64
+}
+
+// AllInAdjusted walks the player's hands in order and replaces the result of
+// every hand that was all-in before the river with the chips the player was
+// expected to win at the moment the money went in. Leave NumIterations at
+// zero to enumerate every run-out exactly.
+func AllInAdjusted(hands []handhistory.Hand, player string, options Options) (Report, error) {
+	report := Report{Player: player}
+
+	for _, hand := range hands {
+		if _, ok := hand.Seat(player); !ok {
+			continue
+		}
+
+		point := Point{HandID: hand.ID, Actual: hand.Net(player)}
+		point.Adjusted = point.Actual
+
+		allIn, ok, err := FindAllIn(hand, options)
+		if err != nil {
+			return report, err
+		}
+
+		if ok && slices.Contains(allIn.Players, player) {
+			point.AllIn = true
+			point.Street = allIn.Street
+			point.Equity = allIn.Equity[player]
+			point.Adjusted = allIn.Expected[player] - hand.Contributions()[player]
+			report.AllInHands++
+		}
+
+		report.Hands++
+		report.Actual += point.Actual
+		report.Adjusted += point.Adjusted
+		point.CumulativeActual = report.Actual
+		point.CumulativeAdjusted = report.Adjusted
+
+		report.Points = append(report.Points, point)
+	}
+
+	return report, nil
+}
+
+// FindAllIn reports whether the hand was all-in before the river with every
+// remaining player's cards shown, and if so each player's equity at the
+// moment the last chips went in.
+func FindAllIn(hand handhistory.Hand, options Options) (AllIn, bool, error) {
+	last := -1
+	anyAllIn := false
+	for i, action := range hand.Actions {
+		switch action.Type {
+		case handhistory.Fold, handhistory.Check, handhistory.Call, handhistory.Bet, handhistory.Raise,
+			handhistory.PostSmallBlind, handhistory.PostBigBlind, handhistory.PostDeadBlind, handhistory.PostAnte:
+			last = i
+			anyAllIn = anyAllIn || action.AllIn
+		}
+	}
+
+	if last < 0 || !anyAllIn || len(hand.Board) != 5 {
+		return AllIn{}, false, nil
+	}
+
+	street := hand.Actions[last].Street
+	if street >= game.River {
+		return AllIn{}, false, nil
+	}
+
+	contributions := hand.Contributions()
+	folded := hand.Folded()
+
+	var players []string
+	config := simulator.AllInConfig{
+		CommunityCards: hand.BoardAt(street),
+		NumIterations:  options.NumIterations,
+		NumConcurrent:  max(options.NumConcurrent, 1),
+		Exact:          options.NumIterations == 0,
+	}
+	if !config.Exact {
+		config.NumConcurrent = min(config.NumConcurrent, config.NumIterations)
+	}
+
+	for _, seat := range hand.Seats {
+		amount, ok := contributions[seat.Player]
+		if !ok {
+			continue
+		}
+
+		cards := hand.HoleCards[seat.Player]
+		if !folded[seat.Player] {
+			if len(cards) != 2 {
+				return AllIn{}, false, nil
+			}
+			players = append(players, seat.Player)
+		} else {
+			cards = nil
+		}
+
+		config.Hands = append(config.Hands, poker.NewHand(cards...))
+		config.Contributions = append(config.Contributions, int(math.Round(amount*chipScale)))
+		config.Folded = append(config.Folded, folded[seat.Player])
+	}
+
+	if len(players) < 2 {
+		return AllIn{}, false, nil
+	}
+
+	result, err := simulator.NewAllInSimulator(config).RunSimulation()
+	if err != nil {
+		return AllIn{}, false, err
+	}
+
+	rakeFactor := 1.0
+	if hand.TotalPot > 0 {
+		rakeFactor = (hand.TotalPot - hand.Rake) / hand.TotalPot
+	}
+
+	allIn := AllIn{
+		Street:   street,
+		Players:  players,
+		Equity:   make(map[string]float64),
+		Expected: make(map[string]float64),
+	}
+
+	index := 0
+	for _, seat := range hand.Seats {
+		if _, ok := contributions[seat.Player]; !ok {
+			continue
+		}
+		if !folded[seat.Player] {
+			allIn.Equity[seat.Player] = result.Equity[index]
+			allIn.Expected[seat.Player] = result.ExpectedChips[index] / chipScale * rakeFactor
+		}
+		allIn.Contested += float64(config.Contributions[index]) / chipScale
+		index++
+	}
+
+	return allIn, true, nil
+}
@@ -0,0 +1,54 @@
+package ev
+
+import (
+	"math"
+	"os"
+	"testing"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/game"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/handhistory"
+)
+
+func TestAllInAdjusted(t *testing.T) {
+	file, err := os.Open("../handhistory/testdata/cash.txt")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer file.Close()
+
+	hands, err := handhistory.ParsePokerStars(file)
+	if err != nil {
+		t.Fatalf("ParsePokerStars() error = %v", err)
+	}
+
+	report, err := AllInAdjusted(hands, "hero", Options{NumConcurrent: 2})
+	if err != nil {
+		t.Fatalf("AllInAdjusted() error = %v", err)
+	}
+
+	if report.Hands != 2 || report.AllInHands != 1 {
+		t.Fatalf("Hands = %d, AllInHands = %d", report.Hands, report.AllInHands)
+	}
+
+	allIn := report.Points[0]
+	if !allIn.AllIn || allIn.Street != game.Turn {
+		t.Errorf("expected a turn all-in, got %+v", allIn)
+	}
+
+	// Hero has two aces left among 44 rivers, and the pot is paid out net
+	// of rake.
+	for _, tt := range []struct {
+		name      string
+		got, want float64
+	}{
+		{"Equity", allIn.Equity, 2.0 / 44},
+		{"Actual", allIn.Actual, -1.50},
+		{"Adjusted", allIn.Adjusted, 2.0/44*3.34 - 1.50},
+		{"Total actual", report.Actual, -1.47},
+		{"Total adjusted", report.Points[1].CumulativeAdjusted, report.Adjusted},
+	} {
+		if math.Abs(tt.got-tt.want) > 1e-6 {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
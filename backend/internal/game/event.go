@@ -0,0 +1,115 @@
+package game
+
+import (
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+)
+
+type Street int
+
+const (
+	Preflop Street = iota
+	Flop
+	Turn
+	River
+	Showdown
+)
+
+func (s Street) String() string {
+	streetStrings := map[Street]string{
+		Preflop:  "Preflop",
+		Flop:     "Flop",
+		Turn:     "Turn",
+		River:    "River",
+		Showdown: "Showdown",
+	}
+
+	if str, exists := streetStrings[s]; exists {
+		return str
+	}
+	return "Unknown Street"
+}
+
+type ActionType int
+
+const (
+	Fold ActionType = iota
+	Check
+	Call
+	Bet
+	Raise
+)
+
+func (a ActionType) String() string {
+	actionStrings := map[ActionType]string{
+		Fold:  "Fold",
+		Check: "Check",
+		Call:  "Call",
+		Bet:   "Bet",
+		Raise: "Raise",
+	}
+
+	if str, exists := actionStrings[a]; exists {
+		return str
+	}
+	return "Unknown Action"
+}
+
+// Action is a player decision. For Bet and Raise, Amount is the player's
+// total bet on the street after the action ("raise to"), for Call it is
+// the number of chips added. Fold and Check ignore it.
+type Action struct {
+	Seat   int
+	Type   ActionType
+	Amount int
+}
+
+type LegalAction struct {
+	Type      ActionType
+	MinAmount int
+	MaxAmount int
+}
+
+type EventType int
+
+const (
+	HandStarted EventType = iota
+	AntePosted
+	BlindPosted
+	HoleCardsDealt
+	ActionTaken
+	BoardDealt
+	HandShown
+	PotAwarded
+	HandEnded
+)
+
+func (e EventType) String() string {
+	eventStrings := map[EventType]string{
+		HandStarted:    "Hand Started",
+		AntePosted:     "Ante Posted",
+		BlindPosted:    "Blind Posted",
+		HoleCardsDealt: "Hole Cards Dealt",
+		ActionTaken:    "Action Taken",
+		BoardDealt:     "Board Dealt",
+		HandShown:      "Hand Shown",
+		PotAwarded:     "Pot Awarded",
+		HandEnded:      "Hand Ended",
+	}
+
+	if str, exists := eventStrings[e]; exists {
+		return str
+	}
+	return "Unknown Event"
+}
+
+// Event is a single entry in the hand's event stream. Seat is -1 for events
+// that do not belong to a player.
+type Event struct {
+	Type        EventType
+	Street      Street
+	Seat        int
+	Action      Action
+	Amount      int
+	Cards       []poker.Card
+	Description string
+}
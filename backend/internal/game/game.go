@@ -0,0 +1,487 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/pot"
+)
+
+var (
+	ErrHandOver     = errors.New("hand is over")
+	ErrNotYourTurn  = errors.New("not this seat's turn to act")
+	ErrIllegalMove  = errors.New("illegal action")
+	ErrInvalidTable = errors.New("invalid table setup")
+)
+
+type Seat struct {
+	Name  string
+	Stack int
+}
+
+type Config struct {
+	SmallBlind int
+	BigBlind   int
+	Ante       int
+	Button     int
+	Seed       int64
+}
+
+type PlayerState struct {
+	Name        string
+	Stack       int
+	HoleCards   []poker.Card
+	Bet         int
+	Contributed int
+	InHand      bool
+	Folded      bool
+	AllIn       bool
+
+	acted    bool
+	canRaise bool
+}
+
+type Game struct {
+	config     Config
+	players    []PlayerState
+	deck       poker.Deck
+	board      []poker.Card
+	street     Street
+	toAct      int
+	currentBet int
+	minRaise   int
+	done       bool
+	payouts    []int
+	events     []Event
+}
+
+// NewGame seats the players, posts antes and blinds and deals hole cards.
+// Seats with an empty stack sit the hand out. The deck is shuffled from
+// config.Seed, so the same seed and actions always replay the same hand.
+func NewGame(config Config, seats []Seat) (*Game, error) {
+	if config.BigBlind <= 0 || config.SmallBlind < 0 || config.Ante < 0 {
+		return nil, fmt.Errorf("%w: blinds and antes must not be negative", ErrInvalidTable)
+	}
+	if config.Button < 0 || config.Button >= len(seats) {
+		return nil, fmt.Errorf("%w: button must point at a seat", ErrInvalidTable)
+	}
+
+	g := &Game{
+		config:  config,
+		players: make([]PlayerState, len(seats)),
+		deck:    poker.NewDeck(),
+		payouts: make([]int, len(seats)),
+	}
+
+	active := 0
+	for i, seat := range seats {
+		g.players[i] = PlayerState{Name: seat.Name, Stack: seat.Stack, InHand: seat.Stack > 0, canRaise: true}
+		if seat.Stack > 0 {
+			active++
+		}
+	}
+
+	if active < 2 {
+		return nil, fmt.Errorf("%w: at least two players need chips", ErrInvalidTable)
+	}
+	if active*2+8 > len(g.deck.Cards) {
+		return nil, fmt.Errorf("%w: too many players", ErrInvalidTable)
+	}
+
+	g.deck.ShuffleWith(rand.New(rand.NewSource(config.Seed)))
+	g.emit(Event{Type: HandStarted, Seat: config.Button})
+
+	if config.Ante > 0 {
+		for seat := range g.players {
+			if g.players[seat].InHand {
+				amount := g.commit(seat, config.Ante)
+				g.players[seat].Bet = 0
+				g.emit(Event{Type: AntePosted, Seat: seat, Amount: amount})
+			}
+		}
+	}
+
+	smallBlind := g.nextSeat(config.Button, g.isInHand)
+	if active == 2 && g.players[config.Button].InHand {
+		smallBlind = config.Button
+	}
+	bigBlind := g.nextSeat(smallBlind, g.isInHand)
+
+	g.emit(Event{Type: BlindPosted, Seat: smallBlind, Amount: g.commit(smallBlind, config.SmallBlind)})
+	g.emit(Event{Type: BlindPosted, Seat: bigBlind, Amount: g.commit(bigBlind, config.BigBlind)})
+
+	for _, player := range g.players {
+		g.currentBet = max(g.currentBet, player.Bet)
+	}
+	g.minRaise = config.BigBlind
+
+	for round := 0; round < 2; round++ {
+		seat := config.Button
+		for i := 0; i < len(g.players); i++ {
+			seat = g.nextSeat(seat, g.isInHand)
+			if len(g.players[seat].HoleCards) == round {
+				g.players[seat].HoleCards = append(g.players[seat].HoleCards, g.deck.Draw(1)...)
+			}
+		}
+	}
+
+	for seat, player := range g.players {
+		if player.InHand {
+			g.emit(Event{Type: HoleCardsDealt, Seat: seat, Cards: slices.Clone(player.HoleCards)})
+		}
+	}
+
+	g.toAct = g.nextSeat(bigBlind, g.needsAction)
+	if g.roundComplete() {
+		g.nextStreet()
+	}
+
+	return g, nil
+}
+
+// Replay rebuilds a hand from its configuration and the actions taken, as
+// recorded by Actions.
+func Replay(config Config, seats []Seat, actions []Action) (*Game, error) {
+	g, err := NewGame(config, seats)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, action := range actions {
+		if err := g.Apply(action); err != nil {
+			return nil, fmt.Errorf("action %d: %w", i, err)
+		}
+	}
+
+	return g, nil
+}
+
+func (g *Game) Done() bool {
+	return g.done
+}
+
+func (g *Game) ToAct() int {
+	if g.done {
+		return -1
+	}
+	return g.toAct
+}
+
+func (g *Game) Street() Street {
+	return g.street
+}
+
+func (g *Game) Board() []poker.Card {
+	return slices.Clone(g.board)
+}
+
+func (g *Game) CurrentBet() int {
+	return g.currentBet
+}
+
+func (g *Game) Config() Config {
+	return g.config
+}
+
+func (g *Game) BigBlind() int {
+	return g.config.BigBlind
+}
+
+func (g *Game) Button() int {
+	return g.config.Button
+}
+
+func (g *Game) Pot() int {
+	total := 0
+	for _, player := range g.players {
+		total += player.Contributed
+	}
+	return total
+}
+
+func (g *Game) Players() []PlayerState {
+	players := slices.Clone(g.players)
+	for i := range players {
+		players[i].HoleCards = slices.Clone(players[i].HoleCards)
+	}
+	return players
+}
+
+// Payouts returns the chips each seat won at the end of the hand.
+func (g *Game) Payouts() []int {
+	return slices.Clone(g.payouts)
+}
+
+func (g *Game) Events() []Event {
+	return slices.Clone(g.events)
+}
+
+func (g *Game) Actions() []Action {
+	var actions []Action
+	for _, event := range g.events {
+		if event.Type == ActionTaken {
+			actions = append(actions, event.Action)
+		}
+	}
+	return actions
+}
+
+func (g *Game) LegalActions() []LegalAction {
+	if g.done {
+		return nil
+	}
+
+	player := g.players[g.toAct]
+	toCall := g.currentBet - player.Bet
+	maxTotal := player.Bet + player.Stack
+
+	var legal []LegalAction
+	if toCall > 0 {
+		legal = append(legal,
+			LegalAction{Type: Fold},
+			LegalAction{Type: Call, MinAmount: min(toCall, player.Stack), MaxAmount: min(toCall, player.Stack)},
+		)
+	} else {
+		legal = append(legal, LegalAction{Type: Check})
+	}
+
+	opponents := 0
+	for seat := range g.players {
+		if seat != g.toAct && g.isActionable(seat) {
+			opponents++
+		}
+	}
+
+	if player.canRaise && player.Stack > toCall && opponents > 0 {
+		if g.currentBet == 0 {
+			legal = append(legal, LegalAction{Type: Bet, MinAmount: min(g.config.BigBlind, maxTotal), MaxAmount: maxTotal})
+		} else {
+			legal = append(legal, LegalAction{Type: Raise, MinAmount: min(g.currentBet+g.minRaise, maxTotal), MaxAmount: maxTotal})
+		}
+	}
+
+	return legal
+}
+
+func (g *Game) Apply(action Action) error {
+	if g.done {
+		return ErrHandOver
+	}
+	if action.Seat != g.toAct {
+		return fmt.Errorf("%w: seat %d acted, seat %d to act", ErrNotYourTurn, action.Seat, g.toAct)
+	}
+
+	var allowed *LegalAction
+	for _, legal := range g.LegalActions() {
+		if legal.Type == action.Type {
+			allowed = &legal
+			break
+		}
+	}
+	if allowed == nil {
+		return fmt.Errorf("%w: %v", ErrIllegalMove, action.Type)
+	}
+
+	player := &g.players[action.Seat]
+
+	switch action.Type {
+	case Fold:
+		player.Folded = true
+	case Call:
+		action.Amount = g.commit(action.Seat, allowed.MinAmount)
+	case Bet, Raise:
+		if action.Amount < allowed.MinAmount || action.Amount > allowed.MaxAmount {
+			return fmt.Errorf("%w: %v to %d, allowed %d to %d",
+				ErrIllegalMove, action.Type, action.Amount, allowed.MinAmount, allowed.MaxAmount)
+		}
+
+		g.commit(action.Seat, action.Amount-player.Bet)
+
+		if raise := action.Amount - g.currentBet; raise >= g.minRaise {
+			g.minRaise = raise
+			for seat := range g.players {
+				if seat != action.Seat {
+					g.players[seat].acted = false
+					g.players[seat].canRaise = true
+				}
+			}
+		}
+		g.currentBet = action.Amount
+	}
+
+	player.acted = true
+	player.canRaise = false
+
+	g.emit(Event{Type: ActionTaken, Seat: action.Seat, Action: action, Amount: action.Amount})
+	g.advance()
+
+	return nil
+}
+
+func (g *Game) advance() {
+	remaining := 0
+	winner := -1
+	for seat, player := range g.players {
+		if player.InHand && !player.Folded {
+			remaining++
+			winner = seat
+		}
+	}
+
+	if remaining == 1 {
+		g.awardUncontested(winner)
+		return
+	}
+
+	if g.roundComplete() {
+		g.nextStreet()
+		return
+	}
+
+	g.toAct = g.nextSeat(g.toAct, g.needsAction)
+}
+
+func (g *Game) nextStreet() {
+	for seat := range g.players {
+		g.players[seat].Bet = 0
+		g.players[seat].acted = false
+		g.players[seat].canRaise = true
+	}
+	g.currentBet = 0
+	g.minRaise = g.config.BigBlind
+
+	var cards []poker.Card
+	switch g.street {
+	case Preflop:
+		g.deck.Draw(1)
+		cards = g.deck.Draw(3)
+	case Flop, Turn:
+		g.deck.Draw(1)
+		cards = g.deck.Draw(1)
+	case River:
+		g.showdown()
+		return
+	}
+
+	g.street++
+	g.board = append(g.board, cards...)
+	g.emit(Event{Type: BoardDealt, Seat: -1, Cards: cards})
+
+	g.toAct = g.nextSeat(g.config.Button, g.needsAction)
+	if g.roundComplete() {
+		g.nextStreet()
+	}
+}
+
+func (g *Game) showdown() {
+	g.street = Showdown
+
+	hands := make([]poker.Hand, len(g.players))
+	contributions := make([]int, len(g.players))
+	folded := make([]bool, len(g.players))
+
+	for seat, player := range g.players {
+		hands[seat] = poker.NewHand(player.HoleCards...)
+		contributions[seat] = player.Contributed
+		folded[seat] = !player.InHand || player.Folded
+	}
+
+	result := poker.Showdown(hands, g.board)
+
+	for seat, player := range g.players {
+		if !folded[seat] {
+			entry, _ := result.Entry(seat)
+			g.emit(Event{Type: HandShown, Seat: seat, Cards: slices.Clone(player.HoleCards), Description: entry.Description})
+		}
+	}
+
+	pots := pot.BuildPots(contributions, folded)
+	g.finish(pot.Distribute(pots, result, g.config.Button, len(g.players)))
+}
+
+func (g *Game) awardUncontested(winner int) {
+	payouts := make([]int, len(g.players))
+	payouts[winner] = g.Pot()
+	g.finish(payouts)
+}
+
+func (g *Game) finish(payouts []int) {
+	for seat, amount := range payouts {
+		if amount > 0 {
+			g.players[seat].Stack += amount
+			g.emit(Event{Type: PotAwarded, Seat: seat, Amount: amount})
+		}
+	}
+
+	g.payouts = payouts
+	g.done = true
+	g.emit(Event{Type: HandEnded, Seat: -1})
+}
+
+func (g *Game) commit(seat int, amount int) int {
+	player := &g.players[seat]
+	amount = min(amount, player.Stack)
+
+	player.Stack -= amount
+	player.Bet += amount
+	player.Contributed += amount
+	if player.Stack == 0 {
+		player.AllIn = true
+	}
+
+	return amount
+}
+
+func (g *Game) emit(event Event) {
+	event.Street = g.street
+	g.events = append(g.events, event)
+}
+
+func (g *Game) isInHand(seat int) bool {
+	return g.players[seat].InHand
+}
+
+func (g *Game) isActionable(seat int) bool {
+	player := g.players[seat]
+	return player.InHand && !player.Folded && !player.AllIn
+}
+
+func (g *Game) needsAction(seat int) bool {
+	player := g.players[seat]
+	return g.isActionable(seat) && (!player.acted || player.Bet < g.currentBet)
+}
+
+// roundComplete reports whether the betting round is over. A lone player
+// who can still act only has to act when facing a bet.
+func (g *Game) roundComplete() bool {
+	actionable := 0
+	for seat := range g.players {
+		if g.isActionable(seat) {
+			actionable++
+		}
+	}
+
+	for seat, player := range g.players {
+		if !g.needsAction(seat) {
+			continue
+		}
+		if actionable > 1 || player.Bet < g.currentBet {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (g *Game) nextSeat(from int, match func(seat int) bool) int {
+	for i := 1; i <= len(g.players); i++ {
+		seat := (from + i) % len(g.players)
+		if match(seat) {
+			return seat
+		}
+	}
+	return from
+}
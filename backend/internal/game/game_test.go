@@ -0,0 +1,164 @@
+package game
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newTestGame(t *testing.T, config Config, stacks ...int) *Game {
+	t.Helper()
+
+	seats := make([]Seat, len(stacks))
+	for i, stack := range stacks {
+		seats[i] = Seat{Name: string(rune('A' + i)), Stack: stack}
+	}
+
+	g, err := NewGame(config, seats)
+	if err != nil {
+		t.Fatalf("NewGame() error = %v", err)
+	}
+	return g
+}
+
+func mustApply(t *testing.T, g *Game, action Action) {
+	t.Helper()
+
+	if err := g.Apply(action); err != nil {
+		t.Fatalf("Apply(%+v) error = %v", action, err)
+	}
+}
+
+func TestNewGamePostsBlindsAndAntes(t *testing.T) {
+	g := newTestGame(t, Config{SmallBlind: 1, BigBlind: 2, Ante: 1, Button: 0}, 100, 100, 100)
+
+	players := g.Players()
+	if players[1].Contributed != 2 || players[2].Contributed != 3 || players[0].Contributed != 1 {
+		t.Errorf("unexpected contributions: %d %d %d", players[0].Contributed, players[1].Contributed, players[2].Contributed)
+	}
+	if g.Pot() != 6 {
+		t.Errorf("Pot() = %d, want 6", g.Pot())
+	}
+	if g.ToAct() != 0 {
+		t.Errorf("ToAct() = %d, want 0", g.ToAct())
+	}
+	for seat, player := range players {
+		if len(player.HoleCards) != 2 {
+			t.Errorf("seat %d has %d hole cards", seat, len(player.HoleCards))
+		}
+	}
+}
+
+func TestHeadsUpButtonPostsSmallBlind(t *testing.T) {
+	g := newTestGame(t, Config{SmallBlind: 1, BigBlind: 2, Button: 1}, 100, 100)
+
+	if g.Players()[1].Bet != 1 || g.ToAct() != 1 {
+		t.Fatalf("button should post the small blind and act first preflop")
+	}
+
+	mustApply(t, g, Action{Seat: 1, Type: Call})
+	mustApply(t, g, Action{Seat: 0, Type: Check})
+
+	if g.Street() != Flop || g.ToAct() != 0 {
+		t.Errorf("big blind should act first on the flop, got street %v seat %d", g.Street(), g.ToAct())
+	}
+}
+
+func TestMinRaise(t *testing.T) {
+	g := newTestGame(t, Config{SmallBlind: 1, BigBlind: 2, Button: 0}, 100, 100, 100)
+
+	if err := g.Apply(Action{Seat: 0, Type: Raise, Amount: 3}); !errors.Is(err, ErrIllegalMove) {
+		t.Errorf("raise to 3 should be illegal, got %v", err)
+	}
+
+	mustApply(t, g, Action{Seat: 0, Type: Raise, Amount: 6})
+
+	for _, legal := range g.LegalActions() {
+		if legal.Type == Raise && legal.MinAmount != 10 {
+			t.Errorf("min re-raise = %d, want 10", legal.MinAmount)
+		}
+	}
+}
+
+func TestIncompleteAllInDoesNotReopenBetting(t *testing.T) {
+	g := newTestGame(t, Config{SmallBlind: 1, BigBlind: 2, Button: 0}, 100, 100, 13)
+
+	mustApply(t, g, Action{Seat: 0, Type: Raise, Amount: 10})
+	mustApply(t, g, Action{Seat: 1, Type: Call})
+	mustApply(t, g, Action{Seat: 2, Type: Raise, Amount: 13})
+
+	for _, legal := range g.LegalActions() {
+		if legal.Type == Raise {
+			t.Fatalf("seat %d should not be allowed to re-raise an incomplete all-in", g.ToAct())
+		}
+	}
+}
+
+func TestFoldAwardsPot(t *testing.T) {
+	g := newTestGame(t, Config{SmallBlind: 1, BigBlind: 2, Button: 0}, 100, 100, 100)
+
+	mustApply(t, g, Action{Seat: 0, Type: Fold})
+	mustApply(t, g, Action{Seat: 1, Type: Fold})
+
+	if !g.Done() {
+		t.Fatal("hand should be over")
+	}
+	if got := g.Payouts(); !reflect.DeepEqual(got, []int{0, 0, 3}) {
+		t.Errorf("Payouts() = %v, want [0 0 3]", got)
+	}
+	if g.Players()[2].Stack != 101 {
+		t.Errorf("big blind stack = %d, want 101", g.Players()[2].Stack)
+	}
+}
+
+func TestAllInRunsOutBoard(t *testing.T) {
+	g := newTestGame(t, Config{SmallBlind: 5, BigBlind: 10, Button: 0, Seed: 7}, 50, 200, 200)
+
+	mustApply(t, g, Action{Seat: 0, Type: Raise, Amount: 50})
+	mustApply(t, g, Action{Seat: 1, Type: Raise, Amount: 200})
+	mustApply(t, g, Action{Seat: 2, Type: Call})
+
+	if !g.Done() || len(g.Board()) != 5 {
+		t.Fatalf("board should be run out, done=%v board=%v", g.Done(), g.Board())
+	}
+
+	total := 0
+	for _, player := range g.Players() {
+		total += player.Stack
+	}
+	if total != 450 {
+		t.Errorf("chips not conserved: %d", total)
+	}
+}
+
+func TestReplayIsDeterministic(t *testing.T) {
+	config := Config{SmallBlind: 1, BigBlind: 2, Button: 2, Seed: 99}
+	seats := []Seat{{"A", 100}, {"B", 100}, {"C", 100}}
+
+	g := newTestGame(t, config, 100, 100, 100)
+	mustApply(t, g, Action{Seat: 2, Type: Raise, Amount: 6})
+	for !g.Done() {
+		action := Action{Seat: g.ToAct(), Type: Call}
+		if g.LegalActions()[0].Type == Check {
+			action.Type = Check
+		}
+		mustApply(t, g, action)
+	}
+
+	replayed, err := Replay(config, seats, g.Actions())
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(g.Events(), replayed.Events()) {
+		t.Error("replayed events differ from the original hand")
+	}
+}
+
+func TestApplyOutOfTurn(t *testing.T) {
+	g := newTestGame(t, Config{SmallBlind: 1, BigBlind: 2, Button: 0}, 100, 100, 100)
+
+	if err := g.Apply(Action{Seat: 1, Type: Call}); !errors.Is(err, ErrNotYourTurn) {
+		t.Errorf("Apply() error = %v, want %v", err, ErrNotYourTurn)
+	}
+}
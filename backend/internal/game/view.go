@@ -0,0 +1,75 @@
+package game
+
+import (
+	"slices"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+)
+
+type SeatView struct {
+	Name   string
+	Stack  int
+	Bet    int
+	InHand bool
+	Folded bool
+	AllIn  bool
+}
+
+// View is what a single seat can see of the table: its own hole cards, the
+// board and the public state of every seat, but never other hole cards.
+type View struct {
+	Seat         int
+	HoleCards    []poker.Card
+	Board        []poker.Card
+	Street       Street
+	Button       int
+	Pot          int
+	CurrentBet   int
+	BigBlind     int
+	Seats        []SeatView
+	LegalActions []LegalAction
+}
+
+func (v View) ToCall() int {
+	return v.CurrentBet - v.Seats[v.Seat].Bet
+}
+
+func (v View) Legal(actionType ActionType) (LegalAction, bool) {
+	for _, legal := range v.LegalActions {
+		if legal.Type == actionType {
+			return legal, true
+		}
+	}
+	return LegalAction{}, false
+}
+
+func (g *Game) View(seat int) View {
+	view := View{
+		Seat:       seat,
+		HoleCards:  slices.Clone(g.players[seat].HoleCards),
+		Board:      g.Board(),
+		Street:     g.street,
+		Button:     g.config.Button,
+		Pot:        g.Pot(),
+		CurrentBet: g.currentBet,
+		BigBlind:   g.config.BigBlind,
+		Seats:      make([]SeatView, len(g.players)),
+	}
+
+	for i, player := range g.players {
+		view.Seats[i] = SeatView{
+			Name:   player.Name,
+			Stack:  player.Stack,
+			Bet:    player.Bet,
+			InHand: player.InHand,
+			Folded: player.Folded,
+			AllIn:  player.AllIn,
+		}
+	}
+
+	if !g.done && seat == g.toAct {
+		view.LegalActions = g.LegalActions()
+	}
+
+	return view
+}
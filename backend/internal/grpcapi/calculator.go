@@ -0,0 +1,218 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/calculatorpb"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/apikeys"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/logging"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+	simulator "github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/simulation"
+)
+
+type calculator struct {
+	calculatorpb.UnimplementedCalculatorServer
+	config Config
+}
+
+var errBusy = errors.New("too many simulations running, try again later")
+
+func (c *calculator) CalculateEquity(ctx context.Context, req *calculatorpb.EquityRequest) (*calculatorpb.EquityResponse, error) {
+	config, release, err := c.start(ctx, req, c.config.MaxIterations)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+	defer release()
+
+	if err := c.config.Keys.ChargeContext(ctx, config.NumIterations); err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	result, err := simulator.NewSimulator(config).RunSimulationContext(ctx, nil)
+	if err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	return equityResponse(config, result, result.Iterations, true), nil
+}
+
+// StreamEquity sends the running result every ProgressInterval, skipping
+// the ones a slow client has not taken yet, and the final result last.
+func (c *calculator) StreamEquity(req *calculatorpb.EquityRequest, stream calculatorpb.Calculator_StreamEquityServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	config, release, err := c.start(ctx, req, c.config.MaxStreamIterations)
+	if err != nil {
+		return statusError(ctx, err)
+	}
+	defer release()
+
+	total := config.NumIterations / config.NumConcurrent * config.NumConcurrent
+	if err := c.config.Keys.ChargeContext(ctx, total); err != nil {
+		return statusError(ctx, err)
+	}
+
+	latest := make(chan *simulator.Result, 1)
+	finished := make(chan struct{})
+	var result *simulator.Result
+	var runErr error
+	go func() {
+		defer close(finished)
+		// progress is called by one worker at a time, so emptying the
+		// channel before sending never blocks.
+		result, runErr = simulator.NewSimulator(config).RunSimulationContext(ctx, func(partial *simulator.Result) {
+			select {
+			case <-latest:
+			default:
+			}
+			latest <- partial
+		})
+	}()
+
+	ticker := time.NewTicker(c.config.ProgressInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-finished:
+			if runErr != nil {
+				return statusError(ctx, runErr)
+			}
+			return stream.Send(equityResponse(config, result, total, true))
+		case <-ticker.C:
+			select {
+			case partial := <-latest:
+				if err := stream.Send(equityResponse(config, partial, total, false)); err != nil {
+					cancel()
+					<-finished
+					return err
+				}
+			default:
+			}
+		}
+	}
+}
+
+func (c *calculator) EvaluateHand(ctx context.Context, req *calculatorpb.EvaluateHandRequest) (*calculatorpb.EvaluateHandResponse, error) {
+	cards := pokerCards(req.GetCards())
+	if len(cards) < 1 || len(cards) > 7 {
+		return nil, statusError(ctx, fmt.Errorf("%w: between 1 and 7 cards are required", simulator.ErrCardCount))
+	}
+	if err := simulator.CheckCards(cards); err != nil {
+		return nil, statusError(ctx, err)
+	}
+
+	hand := poker.NewHand(cards...)
+	rank := hand.EvaluateHandStrenght(nil)
+
+	return &calculatorpb.EvaluateHandResponse{
+		Category:    rank.Type.String(),
+		Description: rank.Describe(),
+		BestHand:    protoCards(rank.BestHand),
+		Score:       int64(rank.Score()),
+		Index:       int32(rank.Index()),
+	}, nil
+}
+
+// start checks the request, clamps it to maxIterations and the server's
+// worker cap with the same defaults as the HTTP API, and takes its workers
+// from the shared pool. release gives them back.
+func (c *calculator) start(ctx context.Context, req *calculatorpb.EquityRequest, maxIterations int) (simulator.Config, func(), error) {
+	config, err := simulator.Request{
+		PlayerCards:    pokerCards(req.GetPlayerCards()),
+		OpponentCards:  pokerCards(req.GetOpponentCards()),
+		CommunityCards: pokerCards(req.GetCommunityCards()),
+		DeadCards:      pokerCards(req.GetDeadCards()),
+		NumIterations:  int(req.GetNumIterations()),
+		NumConcurrent:  int(req.GetNumConcurrent()),
+	}.Config(maxIterations, c.config.MaxConcurrent)
+	if err != nil {
+		return simulator.Config{}, nil, err
+	}
+
+	slog.DebugContext(ctx, "simulation request",
+		logging.Cards(logging.PlayerCardsKey, config.PlayerHand.Cards),
+		logging.Cards(logging.OpponentCardsKey, config.OpponentHand.Cards),
+		logging.Cards(logging.CommunityCardsKey, config.CommunityCards),
+		logging.Cards(logging.DeadCardsKey, config.DeadCards),
+		"iterations", config.NumIterations,
+		"workers", config.NumConcurrent,
+	)
+
+	workers, ok := c.config.Workers.TryAcquire(config.NumConcurrent)
+	if !ok {
+		return simulator.Config{}, nil, errBusy
+	}
+	config.NumConcurrent = workers
+
+	return config, func() { c.config.Workers.Release(workers) }, nil
+}
+
+func equityResponse(config simulator.Config, result *simulator.Result, total int, done bool) *calculatorpb.EquityResponse {
+	return &calculatorpb.EquityResponse{
+		WinProbability:  result.WinProbability,
+		LoseProbability: result.LoseProbability,
+		TieProbability:  result.TieProbability,
+		Iterations:      int32(result.Iterations),
+		PlayerHand:      config.PlayerHand.EvaluateHandStrenght(config.CommunityCards).Describe(),
+		Total:           int32(total),
+		Done:            done,
+	}
+}
+
+func pokerCards(cards []*calculatorpb.Card) []poker.Card {
+	if len(cards) == 0 {
+		return nil
+	}
+	converted := make([]poker.Card, len(cards))
+	for i, card := range cards {
+		converted[i] = poker.Card{Rank: poker.Rank(card.GetRank()), Suit: poker.Suit(card.GetSuit())}
+	}
+	return converted
+}
+
+func protoCards(cards []poker.Card) []*calculatorpb.Card {
+	converted := make([]*calculatorpb.Card, len(cards))
+	for i, card := range cards {
+		converted[i] = &calculatorpb.Card{Rank: int32(card.Rank), Suit: int32(card.Suit)}
+	}
+	return converted
+}
+
+// statusError maps an error to the gRPC status the HTTP API's status code
+// corresponds to. Errors that ask the client to come back later carry a
+// RetryInfo detail, the gRPC counterpart of Retry-After.
+func statusError(ctx context.Context, err error) error {
+	switch {
+	case simulator.IsInvalid(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, errBusy):
+		return retryLater(codes.ResourceExhausted, err.Error(), time.Second)
+	case errors.Is(err, apikeys.ErrQuotaExceeded):
+		now := time.Now()
+		return retryLater(codes.ResourceExhausted, err.Error(), apikeys.QuotaResets(now).Sub(now))
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	}
+
+	slog.ErrorContext(ctx, "simulation failed", "error", err)
+	return status.Error(codes.Internal, "internal server error")
+}
+
+func retryLater(code codes.Code, message string, wait time.Duration) error {
+	st, err := status.New(code, message).WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(wait)})
+	if err != nil {
+		return status.Error(code, message)
+	}
+	return st.Err()
+}
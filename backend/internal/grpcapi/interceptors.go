@@ -0,0 +1,162 @@
+package grpcapi
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/calculatorpb"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/apikeys"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/logging"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/metrics"
+)
+
+var (
+	callsTotal = metrics.Default.NewCounterVec("grpc_requests_total",
+		"gRPC calls served, by method and status code.", "method", "code")
+	callDuration = metrics.Default.NewHistogramVec("grpc_request_duration_seconds",
+		"Time taken to serve gRPC calls, by method.", metrics.DefBuckets, "method")
+)
+
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// requestID reuses a well-formed x-request-id sent by the client, like the
+// HTTP API does with X-Request-ID, or makes a new one.
+func requestID(ctx context.Context) string {
+	if ids := metadata.ValueFromIncomingContext(ctx, "x-request-id"); len(ids) > 0 && validRequestID.MatchString(ids[0]) {
+		return ids[0]
+	}
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// observe runs one call with a request ID in its context, turns a panic
+// into an Internal error, and logs, counts and times the call.
+func observe(ctx context.Context, method string, call func(ctx context.Context) error) (err error) {
+	start := time.Now()
+	ctx = logging.WithRequestID(ctx, requestID(ctx))
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			slog.ErrorContext(ctx, "panic serving call",
+				"method", method,
+				"panic", fmt.Sprint(recovered),
+				"stack", string(debug.Stack()),
+			)
+			err = status.Error(codes.Internal, "internal server error")
+		}
+
+		code := status.Code(err)
+		level := slog.LevelInfo
+		switch code {
+		case codes.Internal, codes.Unknown, codes.DataLoss:
+			level = slog.LevelError
+		}
+		slog.Log(ctx, level, "call",
+			"method", method,
+			"code", code.String(),
+			"duration", time.Since(start),
+		)
+		callsTotal.With(method, code.String()).Inc()
+		callDuration.With(method).Observe(time.Since(start).Seconds())
+	}()
+
+	return call(ctx)
+}
+
+func observeUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	err = observe(ctx, info.FullMethod, func(ctx context.Context) error {
+		grpc.SetHeader(ctx, metadata.Pairs("x-request-id", logging.RequestID(ctx)))
+		resp, err = handler(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+func observeStream(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return observe(stream.Context(), info.FullMethod, func(ctx context.Context) error {
+		stream.SetHeader(metadata.Pairs("x-request-id", logging.RequestID(ctx)))
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	})
+}
+
+// contextStream is a stream with a different context.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// authenticate checks the API key of a Calculator call, sent as x-api-key
+// or as a bearer token in authorization metadata, with the same rules as
+// the HTTP API, and then applies the client's rate limit. Failed attempts
+// are charged to the client's IP address. Health checks and reflection are
+// let through.
+func (c Config) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if !strings.HasPrefix(method, "/"+calculatorpb.Calculator_ServiceDesc.ServiceName+"/") {
+		return ctx, nil
+	}
+
+	var apiKey, authorization string
+	if keys := metadata.ValueFromIncomingContext(ctx, "x-api-key"); len(keys) > 0 {
+		apiKey = keys[0]
+	}
+	if values := metadata.ValueFromIncomingContext(ctx, "authorization"); len(values) > 0 {
+		authorization = values[0]
+	}
+
+	ctx, ok := c.Keys.AuthenticateContext(ctx, apikeys.Secret(apiKey, authorization), c.AllowAnonymous)
+	if !ok {
+		if allowed, wait := c.RateLimiter.Allow(c.clientKey(ctx), time.Now()); !allowed {
+			return ctx, retryLater(codes.ResourceExhausted, "too many requests", wait)
+		}
+		return ctx, status.Error(codes.Unauthenticated, "invalid or missing API key")
+	}
+
+	if ok, wait := c.RateLimiter.Allow(c.clientKey(ctx), time.Now()); !ok {
+		return ctx, retryLater(codes.ResourceExhausted, "too many requests", wait)
+	}
+	return ctx, nil
+}
+
+// clientKey identifies the client like the HTTP API does, so that both
+// share rate limit buckets.
+func (c Config) clientKey(ctx context.Context) string {
+	addr := "unknown"
+	if p, ok := peer.FromContext(ctx); ok {
+		addr = p.Addr.String()
+	}
+	return apikeys.ClientKey(ctx, addr, metadata.ValueFromIncomingContext(ctx, "x-forwarded-for"), c.TrustProxy)
+}
+
+func (c Config) authenticateUnary(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := c.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (c Config) authenticateStream(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := c.authenticate(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+}
@@ -0,0 +1,96 @@
+// Package grpcapi serves the Calculator gRPC service defined in
+// calculatorpb, next to the HTTP API and with the same limits, API keys and
+// shared worker pool.
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/calculatorpb"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/apikeys"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/ratelimit"
+)
+
+// Config holds the limits of the gRPC API. MaxStreamIterations caps
+// StreamEquity, which reports progress like a job and so may run longer
+// than CalculateEquity. Workers, Keys and RateLimiter may be nil and are
+// meant to be the same ones the HTTP API uses.
+type Config struct {
+	MaxIterations       int
+	MaxConcurrent       int
+	MaxStreamIterations int
+	Workers             *ratelimit.WorkerPool
+	Keys                *apikeys.Store
+	AllowAnonymous      bool
+	RateLimiter         *ratelimit.Limiter
+	TrustProxy          bool
+	// ProgressInterval is how often StreamEquity sends the running result,
+	// 250ms when zero.
+	ProgressInterval time.Duration
+}
+
+// Server is a gRPC server with the Calculator, health and reflection
+// services registered.
+type Server struct {
+	*grpc.Server
+	health *health.Server
+}
+
+func NewServer(config Config) *Server {
+	if config.ProgressInterval <= 0 {
+		config.ProgressInterval = 250 * time.Millisecond
+	}
+
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(observeUnary, config.authenticateUnary),
+		grpc.ChainStreamInterceptor(observeStream, config.authenticateStream),
+	)
+
+	calculatorpb.RegisterCalculatorServer(server, &calculator{config: config})
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(calculatorpb.Calculator_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	reflection.Register(server)
+
+	return &Server{Server: server, health: healthServer}
+}
+
+// Drain reports every service as not serving to health checks, so load
+// balancers stop sending new calls, while the server keeps answering.
+func (s *Server) Drain() {
+	if s == nil {
+		return
+	}
+	s.health.Shutdown()
+}
+
+// Shutdown stops accepting calls and waits for the running ones to finish
+// until ctx is done, then cancels them and returns ctx.Err().
+func (s *Server) Shutdown(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.Stop()
+		<-stopped
+		return ctx.Err()
+	}
+}
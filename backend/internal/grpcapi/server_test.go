@@ -0,0 +1,329 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/calculatorpb"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/apikeys"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/ratelimit"
+)
+
+// dial starts a server over an in-memory connection and returns a client
+// connection to it.
+func dial(t *testing.T, config Config) (*Server, *grpc.ClientConn) {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(config)
+	go server.Serve(listener)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+	return server, conn
+}
+
+func card(rank, suit int32) *calculatorpb.Card {
+	return &calculatorpb.Card{Rank: rank, Suit: suit}
+}
+
+var aces = []*calculatorpb.Card{card(14, 0), card(14, 1)}
+
+func TestCalculateEquity(t *testing.T) {
+	_, conn := dial(t, Config{MaxIterations: 5_000, MaxConcurrent: 4})
+	client := calculatorpb.NewCalculatorClient(conn)
+
+	tests := []struct {
+		name string
+		req  *calculatorpb.EquityRequest
+		code codes.Code
+	}{
+		{"aces", &calculatorpb.EquityRequest{PlayerCards: aces, NumIterations: 4_000}, codes.OK},
+		{"one card", &calculatorpb.EquityRequest{PlayerCards: aces[:1]}, codes.InvalidArgument},
+		{"duplicate card", &calculatorpb.EquityRequest{PlayerCards: aces, CommunityCards: aces[:1]}, codes.InvalidArgument},
+		{"invalid card", &calculatorpb.EquityRequest{PlayerCards: []*calculatorpb.Card{card(14, 0), card(15, 0)}}, codes.InvalidArgument},
+		{"three opponent cards", &calculatorpb.EquityRequest{PlayerCards: aces, OpponentCards: []*calculatorpb.Card{card(2, 0), card(3, 0), card(4, 0)}}, codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.CalculateEquity(context.Background(), tt.req)
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("CalculateEquity() code = %v, want %v (%v)", code, tt.code, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if resp.Iterations != 4_000 || !resp.Done || resp.PlayerHand != "Pair of Aces" {
+				t.Errorf("CalculateEquity() = %v", resp)
+			}
+			if resp.WinProbability < 0.75 || resp.WinProbability > 0.9 {
+				t.Errorf("WinProbability = %.3f, want about 0.85", resp.WinProbability)
+			}
+		})
+	}
+}
+
+func TestStreamEquity(t *testing.T) {
+	_, conn := dial(t, Config{MaxIterations: 1_000, MaxConcurrent: 4, MaxStreamIterations: 200_000, ProgressInterval: time.Millisecond})
+	client := calculatorpb.NewCalculatorClient(conn)
+
+	stream, err := client.StreamEquity(context.Background(), &calculatorpb.EquityRequest{PlayerCards: aces, NumIterations: 200_000, NumConcurrent: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var responses []*calculatorpb.EquityResponse
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv() error = %v", err)
+		}
+		responses = append(responses, resp)
+	}
+
+	if len(responses) < 2 {
+		t.Fatalf("got %d messages, want progress before the result", len(responses))
+	}
+	for i, resp := range responses {
+		if resp.Total != 200_000 {
+			t.Errorf("message %d total = %d, want 200000", i, resp.Total)
+		}
+		if i > 0 && resp.Iterations < responses[i-1].Iterations {
+			t.Errorf("message %d went back from %d to %d iterations", i, responses[i-1].Iterations, resp.Iterations)
+		}
+		if last := i == len(responses)-1; resp.Done != last {
+			t.Errorf("message %d done = %v, want %v", i, resp.Done, last)
+		}
+	}
+	if final := responses[len(responses)-1]; final.Iterations != 200_000 {
+		t.Errorf("final iterations = %d, want 200000", final.Iterations)
+	}
+}
+
+func TestStreamEquityCanceled(t *testing.T) {
+	workers := ratelimit.NewWorkerPool(4)
+	_, conn := dial(t, Config{MaxConcurrent: 4, MaxStreamIterations: 100_000_000, Workers: workers, ProgressInterval: time.Millisecond})
+	client := calculatorpb.NewCalculatorClient(conn)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.StreamEquity(ctx, &calculatorpb.EquityRequest{PlayerCards: aces, NumIterations: 100_000_000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv() error = %v", err)
+	}
+	cancel()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for workers.InUse() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("workers still in use after the client went away: %d", workers.InUse())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestEvaluateHand(t *testing.T) {
+	_, conn := dial(t, Config{})
+	client := calculatorpb.NewCalculatorClient(conn)
+
+	tests := []struct {
+		name        string
+		cards       []*calculatorpb.Card
+		category    string
+		description string
+		index       int32
+		code        codes.Code
+	}{
+		{
+			name:        "royal flush",
+			cards:       []*calculatorpb.Card{card(14, 3), card(13, 3), card(12, 3), card(11, 3), card(10, 3), card(2, 0), card(3, 1)},
+			category:    "Royal Flush",
+			description: "Royal Flush",
+			index:       1,
+		},
+		{
+			name:        "full house",
+			cards:       []*calculatorpb.Card{card(13, 0), card(13, 1), card(13, 2), card(7, 0), card(7, 3), card(2, 0)},
+			category:    "Full House",
+			description: "Full House, Kings full of Sevens",
+			index:       185,
+		},
+		{
+			name:        "hole cards only",
+			cards:       aces,
+			category:    "Pair",
+			description: "Pair of Aces",
+		},
+		{name: "no cards", code: codes.InvalidArgument},
+		{name: "duplicate", cards: []*calculatorpb.Card{card(2, 0), card(2, 0)}, code: codes.InvalidArgument},
+		{name: "invalid suit", cards: []*calculatorpb.Card{card(2, 4)}, code: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.EvaluateHand(context.Background(), &calculatorpb.EvaluateHandRequest{Cards: tt.cards})
+			if code := status.Code(err); code != tt.code {
+				t.Fatalf("EvaluateHand() code = %v, want %v (%v)", code, tt.code, err)
+			}
+			if err != nil {
+				return
+			}
+
+			if resp.Category != tt.category || resp.Description != tt.description || resp.Index != tt.index {
+				t.Errorf("EvaluateHand() = %v", resp)
+			}
+		})
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	keys, err := apikeys.NewStore([]apikeys.Key{{Name: "partner", Key: "partner-key", IterationsPerDay: 1_500}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer keys.Close()
+
+	_, conn := dial(t, Config{MaxIterations: 1_000, MaxConcurrent: 2, Keys: keys})
+	client := calculatorpb.NewCalculatorClient(conn)
+	req := &calculatorpb.EquityRequest{PlayerCards: aces, NumIterations: 1_000}
+
+	tests := []struct {
+		name string
+		md   metadata.MD
+		code codes.Code
+	}{
+		{"no key", nil, codes.Unauthenticated},
+		{"wrong key", metadata.Pairs("x-api-key", "nope"), codes.Unauthenticated},
+		{"key", metadata.Pairs("x-api-key", "partner-key"), codes.OK},
+		{"over quota", metadata.Pairs("authorization", "Bearer partner-key"), codes.ResourceExhausted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewOutgoingContext(context.Background(), tt.md)
+			_, err := client.CalculateEquity(ctx, req)
+			if code := status.Code(err); code != tt.code {
+				t.Errorf("CalculateEquity() code = %v, want %v (%v)", code, tt.code, err)
+			}
+		})
+	}
+
+	if usage := keys.Usage(); usage[0].Requests != 2 || usage[0].Iterations != 1_000 || usage[0].Rejected != 1 {
+		t.Errorf("usage = %+v", usage[0])
+	}
+
+	health, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil || health.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("health check without a key = %v, %v", health, err)
+	}
+}
+
+func TestAuthenticationLimitsFailures(t *testing.T) {
+	keys, err := apikeys.NewStore([]apikeys.Key{{Name: "partner", Key: "partner-key"}}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer keys.Close()
+
+	_, conn := dial(t, Config{MaxIterations: 1_000, MaxConcurrent: 2, Keys: keys, RateLimiter: ratelimit.NewLimiter(0.001, 2)})
+	client := calculatorpb.NewCalculatorClient(conn)
+	req := &calculatorpb.EquityRequest{PlayerCards: aces, NumIterations: 1_000}
+
+	for i, want := range []codes.Code{codes.Unauthenticated, codes.Unauthenticated, codes.ResourceExhausted} {
+		ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("x-api-key", "guess"))
+		if _, err := client.CalculateEquity(ctx, req); status.Code(err) != want {
+			t.Errorf("attempt %d: code = %v, want %v (%v)", i+1, status.Code(err), want, err)
+		}
+	}
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("x-api-key", "partner-key"))
+	if _, err := client.CalculateEquity(ctx, req); err != nil {
+		t.Errorf("CalculateEquity() with a valid key error = %v", err)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	workers := ratelimit.NewWorkerPool(2)
+	_, conn := dial(t, Config{
+		MaxIterations: 1_000,
+		MaxConcurrent: 2,
+		Workers:       workers,
+		RateLimiter:   ratelimit.NewLimiter(0.001, 2),
+	})
+	client := calculatorpb.NewCalculatorClient(conn)
+	req := &calculatorpb.EquityRequest{PlayerCards: aces}
+
+	held, _ := workers.TryAcquire(2)
+	_, err := client.CalculateEquity(context.Background(), req)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("CalculateEquity() with no free workers code = %v, want ResourceExhausted", status.Code(err))
+	}
+	workers.Release(held)
+
+	if _, err := client.CalculateEquity(context.Background(), req); err != nil {
+		t.Errorf("CalculateEquity() error = %v", err)
+	}
+
+	_, err = client.CalculateEquity(context.Background(), req)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("CalculateEquity() over the rate limit code = %v, want ResourceExhausted", status.Code(err))
+	}
+	var retry *errdetails.RetryInfo
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			retry = info
+		}
+	}
+	if retry == nil || retry.RetryDelay.AsDuration() <= 0 {
+		t.Errorf("rate limited error has no retry delay: %v", status.Convert(err).Details())
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	server, conn := dial(t, Config{})
+	health := healthpb.NewHealthClient(conn)
+
+	server.Drain()
+	resp, err := health.Check(context.Background(), &healthpb.HealthCheckRequest{Service: calculatorpb.Calculator_ServiceDesc.ServiceName})
+	if err != nil || resp.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("health check after Drain() = %v, %v", resp, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		t.Errorf("Shutdown() error = %v", err)
+	}
+}
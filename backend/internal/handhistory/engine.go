@@ -0,0 +1,123 @@
+package handhistory
+
+import (
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/game"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+)
+
+// FromGame records a hand played on the game engine in the same shape as a
+// parsed history. Seats are numbered from 1, every dealt hand is known and
+// chips the engine handed back as part of the payout are written as
+// uncalled bets instead.
+func FromGame(id string, g *game.Game) Hand {
+	config := g.Config()
+	players := g.Players()
+	payouts := g.Payouts()
+
+	hand := Hand{
+		ID:         id,
+		Game:       "Hold'em No Limit",
+		SmallBlind: float64(config.SmallBlind),
+		BigBlind:   float64(config.BigBlind),
+		Ante:       float64(config.Ante),
+		MaxPlayers: len(players),
+		ButtonSeat: config.Button + 1,
+		Board:      g.Board(),
+		HoleCards:  make(map[string][]poker.Card),
+		Winnings:   make(map[string]float64),
+	}
+
+	stacks := make([]int, len(players))
+	for seat, player := range players {
+		stacks[seat] = player.Stack - payouts[seat] + player.Contributed
+		hand.Seats = append(hand.Seats, Seat{
+			Number:     seat + 1,
+			Player:     player.Name,
+			Stack:      float64(stacks[seat]),
+			SittingOut: !player.InHand,
+		})
+		if player.InHand {
+			hand.HoleCards[player.Name] = player.HoleCards
+		}
+	}
+
+	blinds := []ActionType{PostSmallBlind, PostBigBlind}
+	bets := make([]int, len(players))
+	street := game.Preflop
+
+	for _, event := range g.Events() {
+		if event.Street != street {
+			street = event.Street
+			clear(bets)
+		}
+
+		action := Action{Street: event.Street}
+		if event.Seat >= 0 {
+			action.Player = players[event.Seat].Name
+		}
+
+		switch event.Type {
+		case game.AntePosted:
+			action.Type = PostAnte
+			action.Amount = float64(event.Amount)
+			stacks[event.Seat] -= event.Amount
+		case game.BlindPosted:
+			action.Type, blinds = blinds[0], blinds[1:]
+			action.Amount = float64(event.Amount)
+			stacks[event.Seat] -= event.Amount
+			bets[event.Seat] += event.Amount
+			if event.Amount == 0 {
+				continue
+			}
+		case game.ActionTaken:
+			chips := 0
+			switch event.Action.Type {
+			case game.Fold:
+				action.Type = Fold
+			case game.Check:
+				action.Type = Check
+			case game.Call:
+				action.Type = Call
+				chips = event.Amount
+			case game.Bet, game.Raise:
+				action.Type = Bet
+				if event.Action.Type == game.Raise {
+					action.Type = Raise
+				}
+				action.To = float64(event.Amount)
+				chips = event.Amount - bets[event.Seat]
+			}
+			action.Amount = float64(chips)
+			stacks[event.Seat] -= chips
+			bets[event.Seat] += chips
+		case game.HandShown:
+			action.Type = Show
+			action.Cards = event.Cards
+		case game.PotAwarded:
+			hand.Winnings[action.Player] += float64(event.Amount)
+			continue
+		default:
+			continue
+		}
+
+		action.AllIn = action.Amount > 0 && stacks[event.Seat] == 0
+		hand.Actions = append(hand.Actions, action)
+	}
+
+	hand.Actions = withUncalledBets(hand.Actions, hand.BigBlind)
+
+	for _, action := range hand.Actions {
+		if action.Type == UncalledBet {
+			hand.Winnings[action.Player] -= action.Amount
+			if hand.Winnings[action.Player] == 0 {
+				delete(hand.Winnings, action.Player)
+			}
+		}
+	}
+
+	for _, amount := range hand.Contributions() {
+		hand.TotalPot += amount
+	}
+
+	return hand
+}
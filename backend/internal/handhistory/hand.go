@@ -0,0 +1,207 @@
+package handhistory
+
+import (
+	"math"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/game"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+)
+
+type ActionType int
+
+const (
+	PostSmallBlind ActionType = iota
+	PostBigBlind
+	PostAnte
+	PostDeadBlind
+	Fold
+	Check
+	Call
+	Bet
+	Raise
+	UncalledBet
+	Show
+	Muck
+)
+
+func (a ActionType) String() string {
+	actionStrings := map[ActionType]string{
+		PostSmallBlind: "Post Small Blind",
+		PostBigBlind:   "Post Big Blind",
+		PostAnte:       "Post Ante",
+		PostDeadBlind:  "Post Dead Blind",
+		Fold:           "Fold",
+		Check:          "Check",
+		Call:           "Call",
+		Bet:            "Bet",
+		Raise:          "Raise",
+		UncalledBet:    "Uncalled Bet",
+		Show:           "Show",
+		Muck:           "Muck",
+	}
+
+	if str, exists := actionStrings[a]; exists {
+		return str
+	}
+	return "Unknown Action"
+}
+
+// Action is one line of betting. Amount is the number of chips the player
+// put in (or got back for UncalledBet); for raises To is the total bet.
+type Action struct {
+	Street game.Street  `json:"street"`
+	Player string       `json:"player"`
+	Type   ActionType   `json:"type"`
+	Amount float64      `json:"amount,omitempty"`
+	To     float64      `json:"to,omitempty"`
+	AllIn  bool         `json:"allIn,omitempty"`
+	Cards  []poker.Card `json:"cards,omitempty"`
+}
+
+type Seat struct {
+	Number     int     `json:"number"`
+	Player     string  `json:"player"`
+	Stack      float64 `json:"stack"`
+	SittingOut bool    `json:"sittingOut,omitempty"`
+}
+
+type Hand struct {
+	ID           string                  `json:"id"`
+	Site         string                  `json:"site,omitempty"`
+	TournamentID string                  `json:"tournamentId,omitempty"`
+	Game         string                  `json:"game,omitempty"`
+	Currency     string                  `json:"currency,omitempty"`
+	SmallBlind   float64                 `json:"smallBlind,omitempty"`
+	BigBlind     float64                 `json:"bigBlind,omitempty"`
+	Ante         float64                 `json:"ante,omitempty"`
+	Time         string                  `json:"time,omitempty"`
+	Table        string                  `json:"table,omitempty"`
+	MaxPlayers   int                     `json:"maxPlayers,omitempty"`
+	ButtonSeat   int                     `json:"buttonSeat,omitempty"`
+	Seats        []Seat                  `json:"seats,omitempty"`
+	Hero         string                  `json:"hero,omitempty"`
+	HoleCards    map[string][]poker.Card `json:"holeCards,omitempty"`
+	Actions      []Action                `json:"actions,omitempty"`
+	Board        []poker.Card            `json:"board,omitempty"`
+	Winnings     map[string]float64      `json:"winnings,omitempty"`
+	TotalPot     float64                 `json:"totalPot,omitempty"`
+	Rake         float64                 `json:"rake,omitempty"`
+}
+
+func (h Hand) IsTournament() bool {
+	return h.TournamentID != ""
+}
+
+func (h Hand) Seat(player string) (Seat, bool) {
+	for _, seat := range h.Seats {
+		if seat.Player == player {
+			return seat, true
+		}
+	}
+	return Seat{}, false
+}
+
+func (h Hand) ActionsOn(street game.Street) []Action {
+	var actions []Action
+	for _, action := range h.Actions {
+		if action.Street == street {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+// BoardAt returns the community cards visible on the given street.
+func (h Hand) BoardAt(street game.Street) []poker.Card {
+	visible := map[game.Street]int{game.Preflop: 0, game.Flop: 3, game.Turn: 4, game.River: 5, game.Showdown: 5}
+	return h.Board[:min(visible[street], len(h.Board))]
+}
+
+// Contributions returns the chips each player put into the pot, antes and
+// dead blinds included and uncalled bets already returned.
+func (h Hand) Contributions() map[string]float64 {
+	contributions := make(map[string]float64)
+	for _, action := range h.Actions {
+		switch action.Type {
+		case PostSmallBlind, PostBigBlind, PostAnte, PostDeadBlind, Call, Bet, Raise:
+			contributions[action.Player] += action.Amount
+		case UncalledBet:
+			contributions[action.Player] -= action.Amount
+		}
+	}
+	return contributions
+}
+
+func (h Hand) Folded() map[string]bool {
+	folded := make(map[string]bool)
+	for _, action := range h.Actions {
+		if action.Type == Fold {
+			folded[action.Player] = true
+		}
+	}
+	return folded
+}
+
+// Net returns what the player won minus what they put in.
+func (h Hand) Net(player string) float64 {
+	return h.Winnings[player] - h.Contributions()[player]
+}
+
+// streetBets is how much each player has put in on the street, not counting
+// antes, which raises are measured on top of.
+func streetBets(actions []Action, street game.Street, bigBlind float64) map[string]float64 {
+	bets := make(map[string]float64)
+	for _, action := range actions {
+		if action.Street != street {
+			continue
+		}
+		switch action.Type {
+		case PostSmallBlind, PostBigBlind, Call, Bet, Raise:
+			bets[action.Player] += action.Amount
+		case PostDeadBlind:
+			bets[action.Player] += bigBlind
+		}
+	}
+	return bets
+}
+
+// withUncalledBets returns the actions with an UncalledBet added after the
+// betting on every street where the largest bet was not matched, the way a
+// site writes it into its own histories. Amounts are rounded to cents.
+func withUncalledBets(actions []Action, bigBlind float64) []Action {
+	var result []Action
+
+	for street := game.Preflop; street <= game.Showdown; street++ {
+		var onStreet []Action
+		last := -1
+		for _, action := range actions {
+			if action.Street != street {
+				continue
+			}
+			onStreet = append(onStreet, action)
+			switch action.Type {
+			case Show, Muck, UncalledBet:
+			default:
+				last = len(onStreet) - 1
+			}
+		}
+
+		bets := streetBets(onStreet, street, bigBlind)
+		leader, first, second := "", 0.0, 0.0
+		for player, bet := range bets {
+			if bet > first {
+				leader, first, second = player, bet, first
+			} else if bet > second {
+				second = bet
+			}
+		}
+
+		result = append(result, onStreet[:last+1]...)
+		if uncalled := math.Round((first-second)*100) / 100; uncalled > 0 {
+			result = append(result, Action{Street: street, Player: leader, Type: UncalledBet, Amount: uncalled})
+		}
+		result = append(result, onStreet[last+1:]...)
+	}
+
+	return result
+}
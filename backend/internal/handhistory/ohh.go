@@ -0,0 +1,453 @@
+package handhistory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/game"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+)
+
+const OHHSpecVersion = "1.4.6"
+
+var ErrUnknownAction = errors.New("unknown action")
+
+// OHH is one hand in the Open Hand History format, the object found under
+// the "ohh" key of every hand in a file. Only the fields this project can
+// fill or use are listed.
+type OHH struct {
+	SpecVersion      string             `json:"spec_version"`
+	SiteName         string             `json:"site_name"`
+	NetworkName      string             `json:"network_name"`
+	Tournament       bool               `json:"tournament"`
+	TournamentInfo   *OHHTournamentInfo `json:"tournament_info,omitempty"`
+	GameNumber       string             `json:"game_number"`
+	StartDateUTC     string             `json:"start_date_utc"`
+	TableName        string             `json:"table_name"`
+	GameType         string             `json:"game_type"`
+	BetLimit         OHHBetLimit        `json:"bet_limit"`
+	TableSize        int                `json:"table_size"`
+	Currency         string             `json:"currency"`
+	DealerSeat       int                `json:"dealer_seat"`
+	SmallBlindAmount float64            `json:"small_blind_amount"`
+	BigBlindAmount   float64            `json:"big_blind_amount"`
+	AnteAmount       float64            `json:"ante_amount"`
+	HeroPlayerID     *int               `json:"hero_player_id,omitempty"`
+	Players          []OHHPlayer        `json:"players"`
+	Rounds           []OHHRound         `json:"rounds"`
+	Pots             []OHHPot           `json:"pots"`
+}
+
+type OHHTournamentInfo struct {
+	TournamentNumber string `json:"tournament_number"`
+}
+
+type OHHBetLimit struct {
+	BetType string `json:"bet_type"`
+}
+
+type OHHPlayer struct {
+	ID            int     `json:"id"`
+	Seat          int     `json:"seat"`
+	Name          string  `json:"name"`
+	StartingStack float64 `json:"starting_stack"`
+	IsSittingOut  bool    `json:"is_sitting_out,omitempty"`
+}
+
+type OHHRound struct {
+	ID      int         `json:"id"`
+	Street  string      `json:"street"`
+	Cards   []string    `json:"cards,omitempty"`
+	Actions []OHHAction `json:"actions"`
+}
+
+// OHHAction amounts are the chips the action put in, except for raises,
+// where the standard records the total the player raised to.
+type OHHAction struct {
+	ActionNumber int      `json:"action_number"`
+	PlayerID     int      `json:"player_id"`
+	Action       string   `json:"action"`
+	Amount       float64  `json:"amount,omitempty"`
+	IsAllIn      bool     `json:"is_allin,omitempty"`
+	Cards        []string `json:"cards,omitempty"`
+}
+
+type OHHPot struct {
+	Number     int         `json:"number"`
+	Amount     float64     `json:"amount"`
+	Rake       float64     `json:"rake"`
+	PlayerWins []OHHWinner `json:"player_wins"`
+}
+
+type OHHWinner struct {
+	PlayerID  int     `json:"player_id"`
+	WinAmount float64 `json:"win_amount"`
+}
+
+type ohhFile struct {
+	OHH OHH `json:"ohh"`
+}
+
+var (
+	ohhActions = map[ActionType]string{
+		PostSmallBlind: "Post SB",
+		PostBigBlind:   "Post BB",
+		PostAnte:       "Post Ante",
+		PostDeadBlind:  "Post Dead",
+		Fold:           "Fold",
+		Check:          "Check",
+		Call:           "Call",
+		Bet:            "Bet",
+		Raise:          "Raise",
+		Show:           "Shows Cards",
+		Muck:           "Mucks Cards",
+	}
+
+	ohhBetTypes = map[string]string{
+		"Hold'em No Limit":  "NL",
+		"Hold'em Pot Limit": "PL",
+		"Hold'em Limit":     "FL",
+		"":                  "NL",
+	}
+
+	ohhStreets = map[string]game.Street{
+		"Preflop":  game.Preflop,
+		"Flop":     game.Flop,
+		"Turn":     game.Turn,
+		"River":    game.River,
+		"Showdown": game.Showdown,
+	}
+)
+
+// ToOHH converts the hand to the Open Hand History format. Players are
+// numbered by their position in Seats, every known hand is written as
+// dealt cards and uncalled bets are left out, since OHH has no action for
+// them.
+func ToOHH(hand Hand) OHH {
+	ohh := OHH{
+		SpecVersion:      OHHSpecVersion,
+		SiteName:         hand.Site,
+		NetworkName:      hand.Site,
+		Tournament:       hand.IsTournament(),
+		GameNumber:       hand.ID,
+		StartDateUTC:     utcTime(hand.Time),
+		TableName:        hand.Table,
+		GameType:         "Holdem",
+		BetLimit:         OHHBetLimit{BetType: ohhBetTypes[hand.Game]},
+		TableSize:        hand.MaxPlayers,
+		Currency:         hand.Currency,
+		DealerSeat:       hand.ButtonSeat,
+		SmallBlindAmount: hand.SmallBlind,
+		BigBlindAmount:   hand.BigBlind,
+		AnteAmount:       hand.Ante,
+		Players:          []OHHPlayer{},
+		Rounds:           []OHHRound{},
+	}
+
+	if hand.IsTournament() {
+		ohh.TournamentInfo = &OHHTournamentInfo{TournamentNumber: hand.TournamentID}
+	}
+
+	ids := make(map[string]int)
+	for i, seat := range hand.Seats {
+		ids[seat.Player] = i
+		ohh.Players = append(ohh.Players, OHHPlayer{
+			ID:            i,
+			Seat:          seat.Number,
+			Name:          seat.Player,
+			StartingStack: seat.Stack,
+			IsSittingOut:  seat.SittingOut,
+		})
+		if seat.Player == hand.Hero {
+			ohh.HeroPlayerID = &i
+		}
+	}
+
+	number := 0
+	newAction := func(player string, name string) OHHAction {
+		number++
+		return OHHAction{ActionNumber: number, PlayerID: ids[player], Action: name}
+	}
+
+	for street := game.Preflop; street <= game.Showdown; street++ {
+		round := OHHRound{ID: len(ohh.Rounds), Street: street.String(), Actions: []OHHAction{}}
+
+		if street > game.Preflop && street < game.Showdown {
+			for _, card := range hand.Board[len(hand.BoardAt(street-1)):len(hand.BoardAt(street))] {
+				round.Cards = append(round.Cards, card.Code())
+			}
+		}
+
+		actions := hand.ActionsOn(street)
+		dealt := street != game.Preflop
+
+		for _, action := range actions {
+			if !dealt && !isPost(action.Type) {
+				round.Actions = append(round.Actions, dealtCards(hand, newAction)...)
+				dealt = true
+			}
+
+			name, ok := ohhActions[action.Type]
+			if !ok {
+				continue
+			}
+
+			entry := newAction(action.Player, name)
+			entry.Amount = action.Amount
+			if action.Type == Raise {
+				entry.Amount = action.To
+			}
+			entry.IsAllIn = action.AllIn
+			entry.Cards = cardCodes(action.Cards)
+			round.Actions = append(round.Actions, entry)
+		}
+
+		if !dealt {
+			round.Actions = append(round.Actions, dealtCards(hand, newAction)...)
+		}
+
+		if street == game.Preflop || len(round.Cards) > 0 || len(round.Actions) > 0 {
+			ohh.Rounds = append(ohh.Rounds, round)
+		}
+	}
+
+	pot := OHHPot{Amount: hand.TotalPot, Rake: hand.Rake, PlayerWins: []OHHWinner{}}
+	for _, seat := range hand.Seats {
+		if amount, ok := hand.Winnings[seat.Player]; ok {
+			pot.PlayerWins = append(pot.PlayerWins, OHHWinner{PlayerID: ids[seat.Player], WinAmount: amount})
+		}
+	}
+	ohh.Pots = []OHHPot{pot}
+
+	return ohh
+}
+
+func isPost(actionType ActionType) bool {
+	switch actionType {
+	case PostSmallBlind, PostBigBlind, PostAnte, PostDeadBlind:
+		return true
+	}
+	return false
+}
+
+func dealtCards(hand Hand, newAction func(player string, name string) OHHAction) []OHHAction {
+	var actions []OHHAction
+	for _, seat := range hand.Seats {
+		if cards, ok := hand.HoleCards[seat.Player]; ok {
+			action := newAction(seat.Player, "Dealt Cards")
+			action.Cards = cardCodes(cards)
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+func cardCodes(cards []poker.Card) []string {
+	var codes []string
+	for _, card := range cards {
+		codes = append(codes, card.Code())
+	}
+	return codes
+}
+
+// utcTime rewrites a PokerStars time such as "2020/01/28 17:10:41 ET" as
+// RFC 3339 in UTC. Times it cannot read are returned unchanged.
+func utcTime(text string) string {
+	if parsed, err := time.Parse(time.RFC3339, text); err == nil {
+		return parsed.UTC().Format(time.RFC3339)
+	}
+
+	if start := strings.Index(text, "["); start >= 0 {
+		text = strings.TrimSuffix(text[start+1:], "]")
+	}
+
+	eastern, err := time.LoadLocation("America/New_York")
+	if err != nil || !strings.HasSuffix(text, " ET") {
+		return text
+	}
+
+	parsed, err := time.ParseInLocation("2006/01/02 15:04:05", strings.TrimSuffix(text, " ET"), eastern)
+	if err != nil {
+		return text
+	}
+	return parsed.UTC().Format(time.RFC3339)
+}
+
+// FromOHH converts an Open Hand History hand. Uncalled bets are worked out
+// from the betting, since the format does not record them.
+func FromOHH(ohh OHH) (Hand, error) {
+	hand := Hand{
+		ID:         ohh.GameNumber,
+		Site:       ohh.SiteName,
+		Currency:   ohh.Currency,
+		SmallBlind: ohh.SmallBlindAmount,
+		BigBlind:   ohh.BigBlindAmount,
+		Ante:       ohh.AnteAmount,
+		Time:       ohh.StartDateUTC,
+		Table:      ohh.TableName,
+		MaxPlayers: ohh.TableSize,
+		ButtonSeat: ohh.DealerSeat,
+		HoleCards:  make(map[string][]poker.Card),
+		Winnings:   make(map[string]float64),
+	}
+
+	if ohh.GameType != "Holdem" {
+		return Hand{}, fmt.Errorf("%w: unsupported game type %q", ErrMalformedHeader, ohh.GameType)
+	}
+	for name, betType := range ohhBetTypes {
+		if betType == ohh.BetLimit.BetType && name != "" {
+			hand.Game = name
+		}
+	}
+	if hand.Game == "" {
+		return Hand{}, fmt.Errorf("%w: unsupported bet type %q", ErrMalformedHeader, ohh.BetLimit.BetType)
+	}
+
+	if ohh.TournamentInfo != nil {
+		hand.TournamentID = ohh.TournamentInfo.TournamentNumber
+	}
+
+	names := make(map[int]string)
+	for _, player := range ohh.Players {
+		names[player.ID] = player.Name
+		hand.Seats = append(hand.Seats, Seat{
+			Number:     player.Seat,
+			Player:     player.Name,
+			Stack:      player.StartingStack,
+			SittingOut: player.IsSittingOut,
+		})
+	}
+
+	if ohh.HeroPlayerID != nil {
+		hand.Hero = names[*ohh.HeroPlayerID]
+	}
+
+	for _, round := range ohh.Rounds {
+		street, ok := ohhStreets[round.Street]
+		if !ok {
+			return Hand{}, fmt.Errorf("%w: unknown street %q", ErrMalformedLine, round.Street)
+		}
+
+		cards, err := parseCardCodes(round.Cards)
+		if err != nil {
+			return Hand{}, err
+		}
+		hand.Board = append(hand.Board, cards...)
+		if len(hand.Board) > 5 {
+			return Hand{}, fmt.Errorf("%w: more than five board cards", ErrInvalidCards)
+		}
+
+		for _, entry := range round.Actions {
+			player, ok := names[entry.PlayerID]
+			if !ok {
+				return Hand{}, fmt.Errorf("%w: player id %d", ErrUnknownPlayer, entry.PlayerID)
+			}
+
+			cards, err := parseCardCodes(entry.Cards)
+			if err != nil {
+				return Hand{}, err
+			}
+
+			action := Action{Street: street, Player: player, Amount: entry.Amount, AllIn: entry.IsAllIn, Cards: cards}
+
+			switch entry.Action {
+			case "Dealt Cards":
+				hand.HoleCards[player] = cards
+				continue
+			case "Sits Down", "Stands Up", "Added Chips":
+				continue
+			}
+
+			found := false
+			for actionType, name := range ohhActions {
+				if name == entry.Action {
+					action.Type, found = actionType, true
+				}
+			}
+			if !found {
+				return Hand{}, fmt.Errorf("%w: %q", ErrUnknownAction, entry.Action)
+			}
+
+			switch action.Type {
+			case Bet:
+				action.To = entry.Amount
+			case Raise:
+				action.To = entry.Amount
+				action.Amount = entry.Amount - streetBets(hand.Actions, street, hand.BigBlind)[player]
+			case Show:
+				hand.HoleCards[player] = cards
+			}
+
+			hand.Actions = append(hand.Actions, action)
+		}
+	}
+
+	hand.Actions = withUncalledBets(hand.Actions, hand.BigBlind)
+
+	for _, pot := range ohh.Pots {
+		hand.TotalPot += pot.Amount
+		hand.Rake += pot.Rake
+		for _, win := range pot.PlayerWins {
+			player, ok := names[win.PlayerID]
+			if !ok {
+				return Hand{}, fmt.Errorf("%w: player id %d", ErrUnknownPlayer, win.PlayerID)
+			}
+			hand.Winnings[player] += win.WinAmount
+		}
+	}
+
+	return hand, nil
+}
+
+func parseCardCodes(codes []string) ([]poker.Card, error) {
+	return parseCards(strings.Join(codes, " "))
+}
+
+// ParseOHH reads a file of Open Hand History hands, each a JSON object with
+// an "ohh" key, one after another. Like ParsePokerStars it keeps the hands
+// it could convert and joins the errors of the rest.
+func ParseOHH(r io.Reader) ([]Hand, error) {
+	decoder := json.NewDecoder(r)
+
+	var hands []Hand
+	var errs []error
+
+	for {
+		var file ohhFile
+		if err := decoder.Decode(&file); err == io.EOF {
+			break
+		} else if err != nil {
+			errs = append(errs, err)
+			break
+		}
+
+		hand, err := FromOHH(file.OHH)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("hand %s: %w", file.OHH.GameNumber, err))
+			continue
+		}
+		hands = append(hands, hand)
+	}
+
+	return hands, errors.Join(errs...)
+}
+
+// WriteOHH writes the hands as an Open Hand History file: one JSON object
+// per hand, separated by blank lines.
+func WriteOHH(w io.Writer, hands []Hand) error {
+	encoder := json.NewEncoder(w)
+	for _, hand := range hands {
+		if err := encoder.Encode(ohhFile{OHH: ToOHH(hand)}); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, "\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package handhistory
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/game"
+)
+
+func TestOHHRoundTrip(t *testing.T) {
+	for _, fixture := range []string{"cash.txt", "tournament.txt"} {
+		t.Run(fixture, func(t *testing.T) {
+			hands, err := parseFixture(t, fixture)
+			if err != nil {
+				t.Fatalf("ParsePokerStars() error = %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := WriteOHH(&buf, hands); err != nil {
+				t.Fatalf("WriteOHH() error = %v", err)
+			}
+			written := buf.String()
+
+			imported, err := ParseOHH(&buf)
+			if err != nil {
+				t.Fatalf("ParseOHH() error = %v", err)
+			}
+			if len(imported) != len(hands) {
+				t.Fatalf("expected %d hands, got %d", len(hands), len(imported))
+			}
+
+			for i := range hands {
+				want := hands[i]
+				want.Time = utcTime(want.Time)
+				if !reflect.DeepEqual(imported[i], want) {
+					t.Errorf("hand %d changed on round trip:\n got %+v\nwant %+v", i, imported[i], want)
+				}
+			}
+
+			buf.Reset()
+			if err := WriteOHH(&buf, imported); err != nil {
+				t.Fatalf("WriteOHH() error = %v", err)
+			}
+			if buf.String() != written {
+				t.Errorf("second export differs from the first")
+			}
+		})
+	}
+}
+
+func TestToOHH(t *testing.T) {
+	hands, err := parseFixture(t, "cash.txt")
+	if err != nil {
+		t.Fatalf("ParsePokerStars() error = %v", err)
+	}
+
+	ohh := ToOHH(hands[0])
+
+	if ohh.GameNumber != "208915563282" || ohh.BetLimit.BetType != "NL" || ohh.DealerSeat != 2 {
+		t.Errorf("unexpected header: %+v", ohh)
+	}
+	if ohh.StartDateUTC != "2020-01-28T22:10:41Z" {
+		t.Errorf("start date = %q", ohh.StartDateUTC)
+	}
+	if ohh.HeroPlayerID == nil || ohh.Players[*ohh.HeroPlayerID].Name != "hero" {
+		t.Errorf("hero = %v", ohh.HeroPlayerID)
+	}
+
+	var streets []string
+	for _, round := range ohh.Rounds {
+		streets = append(streets, round.Street)
+	}
+	if !reflect.DeepEqual(streets, []string{"Preflop", "Flop", "Turn", "River", "Showdown"}) {
+		t.Errorf("rounds = %v", streets)
+	}
+	if !reflect.DeepEqual(ohh.Rounds[2].Cards, []string{"7s"}) {
+		t.Errorf("turn cards = %v", ohh.Rounds[2].Cards)
+	}
+
+	preflop := ohh.Rounds[0].Actions
+	if preflop[2].Action != "Dealt Cards" || preflop[4].Action != "Raise" || !almostEqual(preflop[4].Amount, 0.06) {
+		t.Errorf("unexpected preflop actions: %+v", preflop)
+	}
+
+	second := ToOHH(hands[1])
+	for _, round := range second.Rounds {
+		for _, action := range round.Actions {
+			if action.Action == "Uncalled Bet" || action.Action == "" {
+				t.Errorf("uncalled bet exported as %+v", action)
+			}
+		}
+	}
+}
+
+func TestFromGame(t *testing.T) {
+	seats := []game.Seat{{Name: "alice", Stack: 1000}, {Name: "bob", Stack: 300}, {Name: "carol", Stack: 600}}
+	g, err := game.NewGame(game.Config{SmallBlind: 5, BigBlind: 10, Ante: 1, Button: 2, Seed: 7}, seats)
+	if err != nil {
+		t.Fatalf("NewGame() error = %v", err)
+	}
+
+	// Everyone calls preflop, then alice, first to act on the flop,
+	// shoves and is called by both shorter stacks.
+	for !g.Done() {
+		action := game.Action{Seat: g.ToAct(), Type: game.Call}
+		if g.Street() == game.Preflop {
+			if _, ok := g.View(g.ToAct()).Legal(game.Check); ok {
+				action.Type = game.Check
+			}
+		} else if bet, ok := g.View(g.ToAct()).Legal(game.Bet); ok {
+			action = game.Action{Seat: g.ToAct(), Type: game.Bet, Amount: bet.MaxAmount}
+		}
+		if err := g.Apply(action); err != nil {
+			t.Fatalf("Apply(%+v) error = %v", action, err)
+		}
+	}
+
+	hand := FromGame("selfplay-1", g)
+
+	players := g.Players()
+	payouts := g.Payouts()
+	for seat, player := range players {
+		want := float64(payouts[seat] - player.Contributed)
+		if got := hand.Net(player.Name); got != want {
+			t.Errorf("%s net = %v, want %v", player.Name, got, want)
+		}
+	}
+
+	uncalled := 0
+	for _, action := range hand.Actions {
+		if action.Type == UncalledBet {
+			uncalled++
+		}
+	}
+	if uncalled != 1 {
+		t.Errorf("expected one uncalled bet, got %d in %+v", uncalled, hand.Actions)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteOHH(&buf, []Hand{hand}); err != nil {
+		t.Fatalf("WriteOHH() error = %v", err)
+	}
+	imported, err := ParseOHH(&buf)
+	if err != nil {
+		t.Fatalf("ParseOHH() error = %v", err)
+	}
+	if !reflect.DeepEqual(imported[0], hand) {
+		t.Errorf("engine hand changed on round trip:\n got %+v\nwant %+v", imported[0], hand)
+	}
+}
+
+func TestParseOHHErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  error
+	}{
+		{
+			name:  "Unknown action",
+			input: `{"ohh":{"game_number":"1","game_type":"Holdem","bet_limit":{"bet_type":"NL"},"players":[{"id":0,"seat":1,"name":"alice"}],"rounds":[{"id":0,"street":"Preflop","actions":[{"action_number":1,"player_id":0,"action":"Straddle","amount":4}]}]}}`,
+			want:  ErrUnknownAction,
+		},
+		{
+			name:  "Unknown player",
+			input: `{"ohh":{"game_number":"1","game_type":"Holdem","bet_limit":{"bet_type":"NL"},"players":[],"rounds":[{"id":0,"street":"Preflop","actions":[{"action_number":1,"player_id":3,"action":"Fold"}]}]}}`,
+			want:  ErrUnknownPlayer,
+		},
+		{
+			name:  "Bad cards",
+			input: `{"ohh":{"game_number":"1","game_type":"Holdem","bet_limit":{"bet_type":"NL"},"players":[],"rounds":[{"id":0,"street":"Flop","cards":["Zz","Ah","Kh"],"actions":[]}]}}`,
+			want:  ErrInvalidCards,
+		},
+		{
+			name:  "Not hold'em",
+			input: `{"ohh":{"game_number":"1","game_type":"Omaha","bet_limit":{"bet_type":"PL"}}}`,
+			want:  ErrMalformedHeader,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseOHH(strings.NewReader(tt.input)); !errors.Is(err, tt.want) {
+				t.Errorf("ParseOHH() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,512 @@
+package handhistory
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/game"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+)
+
+type ParseError struct {
+	Line int
+	Text string
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %v: %q", e.Line, e.Err, e.Text)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	ErrMalformedHeader = errors.New("malformed hand header")
+	ErrMalformedLine   = errors.New("unrecognised line")
+	ErrUnknownPlayer   = errors.New("unknown player")
+	ErrInvalidAmount   = errors.New("invalid amount")
+	ErrInvalidCards    = errors.New("invalid cards")
+)
+
+var (
+	headerPattern   = regexp.MustCompile(`^PokerStars (?:Zoom )?Hand #(\d+):\s+(?:Tournament #(\d+), .*?\s)?(Hold'em (?:No Limit|Pot Limit|Limit))\s+(?:- Level \S+ )?\(([^)]+)\) - (.+)$`)
+	tablePattern    = regexp.MustCompile(`^Table '([^']+)' (\d+)-max (?:\(Play Money\) )?Seat #(\d+) is the button$`)
+	seatPattern     = regexp.MustCompile(`^Seat (\d+): (.+) \((\S+) in chips(?:, [^)]*)?\)( is sitting out| out of hand.*)?$`)
+	streetPattern   = regexp.MustCompile(`^\*\*\* (HOLE CARDS|FLOP|TURN|RIVER|SHOW DOWN|SUMMARY) \*\*\*(.*)$`)
+	dealtPattern    = regexp.MustCompile(`^Dealt to (.+?) \[([^\]]+)\]$`)
+	uncalledPattern = regexp.MustCompile(`^Uncalled bet \((\S+)\) returned to (.+)$`)
+	collectPattern  = regexp.MustCompile(`^(.+) collected (\S+) from (?:side |main )?pot(?:-\d+)?$`)
+	totalPotPattern = regexp.MustCompile(`^Total pot (\S+) .*\| Rake (\S+)`)
+	boardPattern    = regexp.MustCompile(`^Board \[([^\]]+)\]$`)
+	cardsPattern    = regexp.MustCompile(`\[([^\]]+)\]`)
+
+	postPattern  = regexp.MustCompile(`^posts (small blind|big blind|the ante|small & big blinds) (\S+)( and is all-in)?$`)
+	callPattern  = regexp.MustCompile(`^(calls|bets) (\S+)( and is all-in)?$`)
+	raisePattern = regexp.MustCompile(`^raises (\S+) to (\S+)( and is all-in)?$`)
+	showPattern  = regexp.MustCompile(`^shows \[([^\]]+)\]`)
+
+	ignoredPatterns = []*regexp.Regexp{
+		regexp.MustCompile(` (joins|leaves) the table`),
+		regexp.MustCompile(` (is|was) (connected|disconnected)`),
+		regexp.MustCompile(` has (returned|timed out)`),
+		regexp.MustCompile(` will be allowed to play after the button$`),
+		regexp.MustCompile(` said, "`),
+		regexp.MustCompile(`: (is sitting out|sits out|sitting out)$`),
+		regexp.MustCompile(` finished the tournament`),
+		regexp.MustCompile(` wins the tournament`),
+		regexp.MustCompile(`^Hand was run `),
+	}
+)
+
+// ParsePokerStars reads every hand in a PokerStars hand history file. Hands
+// that fail to parse are skipped and their errors, each a *ParseError, are
+// joined into the returned error, so the remaining hands are still usable.
+func ParsePokerStars(r io.Reader) ([]Hand, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var hands []Hand
+	var errs []error
+	var block []string
+	start, lineNumber := 0, 0
+
+	flush := func() {
+		if len(block) == 0 {
+			return
+		}
+		hand, err := parsePokerStarsHand(block, start)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			hands = append(hands, hand)
+		}
+		block = nil
+	}
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+
+		if line == "" {
+			flush()
+			continue
+		}
+		if len(block) == 0 {
+			start = lineNumber
+		}
+		block = append(block, line)
+	}
+	flush()
+
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return hands, errors.Join(errs...)
+}
+
+type pokerStarsParser struct {
+	hand    Hand
+	street  game.Street
+	summary bool
+	line    int
+}
+
+func parsePokerStarsHand(lines []string, firstLine int) (Hand, error) {
+	p := &pokerStarsParser{
+		hand: Hand{
+			Site:      "PokerStars",
+			HoleCards: make(map[string][]poker.Card),
+			Winnings:  make(map[string]float64),
+		},
+	}
+
+	for i, line := range lines {
+		p.line = firstLine + i
+
+		var err error
+		switch i {
+		case 0:
+			err = p.parseHeader(line)
+		case 1:
+			err = p.parseTable(line)
+		default:
+			err = p.parseLine(line)
+		}
+
+		if err != nil {
+			return Hand{}, &ParseError{Line: p.line, Text: line, Err: err}
+		}
+	}
+
+	return p.hand, nil
+}
+
+func (p *pokerStarsParser) parseHeader(line string) error {
+	match := headerPattern.FindStringSubmatch(line)
+	if match == nil {
+		return ErrMalformedHeader
+	}
+
+	p.hand.ID = match[1]
+	p.hand.TournamentID = match[2]
+	p.hand.Game = match[3]
+	p.hand.Time = match[5]
+
+	stakes := strings.Fields(match[4])
+	if len(stakes) > 1 {
+		p.hand.Currency = stakes[1]
+	}
+
+	blinds := strings.Split(stakes[0], "/")
+	if len(blinds) != 2 {
+		return ErrMalformedHeader
+	}
+
+	var err error
+	if p.hand.SmallBlind, err = parseAmount(blinds[0]); err != nil {
+		return err
+	}
+	if p.hand.BigBlind, err = parseAmount(blinds[1]); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (p *pokerStarsParser) parseTable(line string) error {
+	match := tablePattern.FindStringSubmatch(line)
+	if match == nil {
+		return ErrMalformedHeader
+	}
+
+	p.hand.Table = match[1]
+	p.hand.MaxPlayers, _ = strconv.Atoi(match[2])
+	p.hand.ButtonSeat, _ = strconv.Atoi(match[3])
+
+	return nil
+}
+
+func (p *pokerStarsParser) parseLine(line string) error {
+	if match := streetPattern.FindStringSubmatch(line); match != nil {
+		return p.parseStreet(match[1], match[2])
+	}
+
+	if p.summary {
+		return p.parseSummary(line)
+	}
+
+	if match := seatPattern.FindStringSubmatch(line); match != nil && len(p.hand.Actions) == 0 {
+		number, _ := strconv.Atoi(match[1])
+		stack, err := parseAmount(match[3])
+		if err != nil {
+			return err
+		}
+		p.hand.Seats = append(p.hand.Seats, Seat{
+			Number:     number,
+			Player:     match[2],
+			Stack:      stack,
+			SittingOut: match[4] != "",
+		})
+		return nil
+	}
+
+	for _, pattern := range ignoredPatterns {
+		if pattern.MatchString(line) {
+			return nil
+		}
+	}
+
+	if match := dealtPattern.FindStringSubmatch(line); match != nil {
+		cards, err := parseCards(match[2])
+		if err != nil {
+			return err
+		}
+		p.hand.Hero = match[1]
+		p.hand.HoleCards[match[1]] = cards
+		return nil
+	}
+
+	if match := uncalledPattern.FindStringSubmatch(line); match != nil {
+		amount, err := parseAmount(match[1])
+		if err != nil {
+			return err
+		}
+		return p.addAction(Action{Player: match[2], Type: UncalledBet, Amount: amount})
+	}
+
+	if match := collectPattern.FindStringSubmatch(line); match != nil {
+		amount, err := parseAmount(match[2])
+		if err != nil {
+			return err
+		}
+		if _, ok := p.hand.Seat(match[1]); !ok {
+			return ErrUnknownPlayer
+		}
+		p.hand.Winnings[match[1]] += amount
+		return nil
+	}
+
+	player, rest, ok := p.splitPlayer(line)
+	if !ok {
+		return ErrMalformedLine
+	}
+
+	return p.parseAction(player, rest)
+}
+
+func (p *pokerStarsParser) parseStreet(name string, cards string) error {
+	switch name {
+	case "HOLE CARDS":
+		p.street = game.Preflop
+		return nil
+	case "SHOW DOWN":
+		p.street = game.Showdown
+		return nil
+	case "SUMMARY":
+		p.summary = true
+		return nil
+	}
+
+	groups := cardsPattern.FindAllStringSubmatch(cards, -1)
+	if len(groups) == 0 {
+		return ErrInvalidCards
+	}
+
+	newCards, err := parseCards(groups[len(groups)-1][1])
+	if err != nil {
+		return err
+	}
+
+	switch name {
+	case "FLOP":
+		p.street = game.Flop
+		if len(newCards) != 3 {
+			return ErrInvalidCards
+		}
+		p.hand.Board = newCards
+	case "TURN", "RIVER":
+		p.street = game.Turn
+		if name == "RIVER" {
+			p.street = game.River
+		}
+		if len(newCards) != 1 || len(p.hand.Board) != int(p.street)+1 {
+			return ErrInvalidCards
+		}
+		p.hand.Board = append(p.hand.Board, newCards...)
+	}
+
+	return nil
+}
+
+func (p *pokerStarsParser) parseSummary(line string) error {
+	if match := totalPotPattern.FindStringSubmatch(line); match != nil {
+		var err error
+		if p.hand.TotalPot, err = parseAmount(match[1]); err != nil {
+			return err
+		}
+		if p.hand.Rake, err = parseAmount(match[2]); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	if match := boardPattern.FindStringSubmatch(line); match != nil {
+		board, err := parseCards(match[1])
+		if err != nil {
+			return err
+		}
+		p.hand.Board = board
+	}
+
+	return nil
+}
+
+func (p *pokerStarsParser) parseAction(player string, rest string) error {
+	action := Action{Player: player}
+
+	switch {
+	case rest == "folds" || strings.HasPrefix(rest, "folds ["):
+		action.Type = Fold
+	case rest == "checks":
+		action.Type = Check
+	case rest == "mucks hand" || rest == "doesn't show hand":
+		action.Type = Muck
+	case postPattern.MatchString(rest):
+		match := postPattern.FindStringSubmatch(rest)
+		action.Type = map[string]ActionType{
+			"small blind":        PostSmallBlind,
+			"big blind":          PostBigBlind,
+			"the ante":           PostAnte,
+			"small & big blinds": PostDeadBlind,
+		}[match[1]]
+		action.AllIn = match[3] != ""
+
+		amount, err := parseAmount(match[2])
+		if err != nil {
+			return err
+		}
+		action.Amount = amount
+
+		if action.Type == PostAnte {
+			p.hand.Ante = max(p.hand.Ante, amount)
+		}
+	case callPattern.MatchString(rest):
+		match := callPattern.FindStringSubmatch(rest)
+		action.Type = Call
+		if match[1] == "bets" {
+			action.Type = Bet
+		}
+		action.AllIn = match[3] != ""
+
+		amount, err := parseAmount(match[2])
+		if err != nil {
+			return err
+		}
+		action.Amount = amount
+		if action.Type == Bet {
+			action.To = amount
+		}
+	case raisePattern.MatchString(rest):
+		match := raisePattern.FindStringSubmatch(rest)
+		action.Type = Raise
+		action.AllIn = match[3] != ""
+
+		to, err := parseAmount(match[2])
+		if err != nil {
+			return err
+		}
+		action.To = to
+		action.Amount = to - p.streetBet(player)
+	case showPattern.MatchString(rest):
+		cards, err := parseCards(showPattern.FindStringSubmatch(rest)[1])
+		if err != nil {
+			return err
+		}
+		action.Type = Show
+		action.Cards = cards
+		p.hand.HoleCards[player] = cards
+	default:
+		return ErrMalformedLine
+	}
+
+	return p.addAction(action)
+}
+
+func (p *pokerStarsParser) addAction(action Action) error {
+	if _, ok := p.hand.Seat(action.Player); !ok {
+		return ErrUnknownPlayer
+	}
+
+	action.Street = p.street
+	p.hand.Actions = append(p.hand.Actions, action)
+
+	return nil
+}
+
+func (p *pokerStarsParser) streetBet(player string) float64 {
+	return streetBets(p.hand.Actions, p.street, p.hand.BigBlind)[player]
+}
+
+// splitPlayer separates "name: action" lines. Names may contain spaces and
+// colons, so the longest seated name that prefixes the line wins.
+func (p *pokerStarsParser) splitPlayer(line string) (string, string, bool) {
+	names := make([]string, 0, len(p.hand.Seats))
+	for _, seat := range p.hand.Seats {
+		names = append(names, seat.Player)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return len(names[i]) > len(names[j])
+	})
+
+	for _, name := range names {
+		if strings.HasPrefix(line, name+": ") {
+			return name, strings.TrimPrefix(line, name+": "), true
+		}
+	}
+
+	return "", "", false
+}
+
+func parseAmount(text string) (float64, error) {
+	cleaned := strings.NewReplacer("$", "", "€", "", "£", "", ",", "").Replace(text)
+	amount, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidAmount, text)
+	}
+	return amount, nil
+}
+
+func parseCards(text string) ([]poker.Card, error) {
+	var cards []poker.Card
+	for _, code := range strings.Fields(text) {
+		card, err := poker.ParseCard(code)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCards, err)
+		}
+		cards = append(cards, card)
+	}
+	return cards, nil
+}
+
+// LoadDir reads both the PokerStars .txt files and the Open Hand History
+// .ohh and .json files in dir. Like ParsePokerStars it returns the hands it
+// could read alongside any parse errors.
+func LoadDir(dir string) ([]Hand, error) {
+	var hands []Hand
+	var errs []error
+
+	for _, source := range []struct {
+		pattern string
+		parse   func(io.Reader) ([]Hand, error)
+	}{
+		{"*.txt", ParsePokerStars},
+		{"*.ohh", ParseOHH},
+		{"*.json", ParseOHH},
+	} {
+		parsed, err := loadDir(dir, source.pattern, source.parse)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		hands = append(hands, parsed...)
+	}
+
+	return hands, errors.Join(errs...)
+}
+
+func loadDir(dir string, pattern string, parse func(io.Reader) ([]Hand, error)) ([]Hand, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, pattern))
+	if err != nil {
+		return nil, err
+	}
+
+	var hands []Hand
+	var errs []error
+
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		parsed, err := parse(file)
+		file.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", filepath.Base(path), err))
+		}
+		hands = append(hands, parsed...)
+	}
+
+	return hands, errors.Join(errs...)
+}
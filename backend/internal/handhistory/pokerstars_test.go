@@ -0,0 +1,181 @@
+package handhistory
+
+import (
+	"errors"
+	"math"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/game"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+)
+
+func parseFixture(t *testing.T, name string) ([]Hand, error) {
+	t.Helper()
+
+	file, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer file.Close()
+
+	return ParsePokerStars(file)
+}
+
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestParsePokerStarsCash(t *testing.T) {
+	hands, err := parseFixture(t, "cash.txt")
+	if err != nil {
+		t.Fatalf("ParsePokerStars() error = %v", err)
+	}
+	if len(hands) != 2 {
+		t.Fatalf("expected 2 hands, got %d", len(hands))
+	}
+
+	hand := hands[0]
+	if hand.ID != "208915563282" || hand.IsTournament() || hand.Currency != "USD" {
+		t.Errorf("unexpected header: %+v", hand)
+	}
+	if !almostEqual(hand.SmallBlind, 0.01) || !almostEqual(hand.BigBlind, 0.02) {
+		t.Errorf("blinds = %v/%v", hand.SmallBlind, hand.BigBlind)
+	}
+	if hand.Table != "Aaltje II" || hand.MaxPlayers != 6 || hand.ButtonSeat != 2 {
+		t.Errorf("unexpected table: %q %d %d", hand.Table, hand.MaxPlayers, hand.ButtonSeat)
+	}
+	if len(hand.Seats) != 5 || !hand.Seats[4].SittingOut {
+		t.Errorf("unexpected seats: %+v", hand.Seats)
+	}
+
+	wantHero := []poker.Card{{Rank: poker.Ace, Suit: poker.Hearts}, {Rank: poker.King, Suit: poker.Diamonds}}
+	if hand.Hero != "hero" || !reflect.DeepEqual(hand.HoleCards["hero"], wantHero) {
+		t.Errorf("hero cards = %v", hand.HoleCards["hero"])
+	}
+	if len(hand.HoleCards["fish: 77"]) != 2 {
+		t.Errorf("shown cards missing for player with a colon in the name")
+	}
+	if len(hand.Board) != 5 {
+		t.Errorf("board = %v", hand.Board)
+	}
+
+	preflop := hand.ActionsOn(game.Preflop)
+	if len(preflop) != 7 || preflop[3].Type != Raise || !almostEqual(preflop[3].To, 0.18) || !almostEqual(preflop[3].Amount, 0.18) {
+		t.Errorf("unexpected preflop actions: %+v", preflop)
+	}
+	if preflop[4].Player != "fish: 77" || !almostEqual(preflop[4].Amount, 0.17) {
+		t.Errorf("unexpected small blind call: %+v", preflop[4])
+	}
+
+	turn := hand.ActionsOn(game.Turn)
+	if len(turn) != 2 || !turn[0].AllIn {
+		t.Errorf("unexpected turn actions: %+v", turn)
+	}
+
+	if !almostEqual(hand.Winnings["fish: 77"], 3.34) || !almostEqual(hand.TotalPot, 3.50) || !almostEqual(hand.Rake, 0.16) {
+		t.Errorf("unexpected winnings: %v pot %v rake %v", hand.Winnings, hand.TotalPot, hand.Rake)
+	}
+
+	second := hands[1]
+	actions := second.Actions
+	last := actions[len(actions)-1]
+	if last.Type != Muck {
+		t.Errorf("expected final muck, got %+v", last)
+	}
+	if actions[len(actions)-2].Type != UncalledBet || !almostEqual(actions[len(actions)-2].Amount, 0.04) {
+		t.Errorf("expected uncalled bet, got %+v", actions[len(actions)-2])
+	}
+}
+
+func TestParsePokerStarsTournament(t *testing.T) {
+	hands, err := parseFixture(t, "tournament.txt")
+	if err != nil {
+		t.Fatalf("ParsePokerStars() error = %v", err)
+	}
+
+	hand := hands[0]
+	if hand.TournamentID != "2801398563" || hand.Currency != "" {
+		t.Errorf("unexpected tournament header: %+v", hand)
+	}
+	if hand.BigBlind != 100 || hand.Ante != 10 {
+		t.Errorf("blinds = %v ante = %v", hand.BigBlind, hand.Ante)
+	}
+	if seat, ok := hand.Seat("hero"); !ok || seat.Stack != 2250 {
+		t.Errorf("hero seat = %+v", seat)
+	}
+	if hand.Winnings["hero"] != 4920 {
+		t.Errorf("hero winnings = %v", hand.Winnings["hero"])
+	}
+	if len(hand.HoleCards) != 3 {
+		t.Errorf("expected three known hands, got %v", hand.HoleCards)
+	}
+
+	raise := hand.ActionsOn(game.Preflop)[5]
+	if raise.Player != "shortstack" || raise.To != 410 || raise.Amount != 410 || !raise.AllIn {
+		t.Errorf("unexpected all-in raise: %+v", raise)
+	}
+}
+
+func TestParsePokerStarsMalformed(t *testing.T) {
+	hands, err := parseFixture(t, "malformed.txt")
+
+	if len(hands) != 1 || hands[0].ID != "3" {
+		t.Errorf("expected only hand 3 to parse, got %d hands", len(hands))
+	}
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected a ParseError, got %v", err)
+	}
+	if parseErr.Line != 8 || !errors.Is(err, ErrMalformedLine) {
+		t.Errorf("unexpected first error: %v", parseErr)
+	}
+	if !errors.Is(err, ErrInvalidCards) {
+		t.Errorf("expected invalid cards error, got %v", err)
+	}
+}
+
+func TestParsePokerStarsErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  error
+	}{
+		{
+			name:  "Not a PokerStars hand",
+			input: "Full Tilt Poker Game #1: Hold'em",
+			want:  ErrMalformedHeader,
+		},
+		{
+			name: "Unknown player",
+			input: strings.Join([]string{
+				"PokerStars Hand #1:  Hold'em No Limit ($0.01/$0.02 USD) - 2020/01/28 17:10:41 ET",
+				"Table 'T' 6-max Seat #1 is the button",
+				"Seat 1: alice ($2 in chips)",
+				"Uncalled bet ($0.01) returned to mallory",
+			}, "\n"),
+			want: ErrUnknownPlayer,
+		},
+		{
+			name: "Bad amount",
+			input: strings.Join([]string{
+				"PokerStars Hand #1:  Hold'em No Limit ($0.01/$0.02 USD) - 2020/01/28 17:10:41 ET",
+				"Table 'T' 6-max Seat #1 is the button",
+				"Seat 1: alice ($2 in chips)",
+				"alice: bets $lots",
+			}, "\n"),
+			want: ErrInvalidAmount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParsePokerStars(strings.NewReader(tt.input)); !errors.Is(err, tt.want) {
+				t.Errorf("ParsePokerStars() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
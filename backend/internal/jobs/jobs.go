@@ -0,0 +1,338 @@
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/metrics"
+)
+
+var (
+	jobsQueued  = metrics.Default.NewGauge("jobs_queued", "Jobs waiting in the queue.")
+	jobsRunning = metrics.Default.NewGauge("jobs_running", "Jobs being run.")
+	jobsTotal   = metrics.Default.NewCounterVec("jobs_finished_total", "Jobs finished, by status.", "status")
+)
+
+type Status string
+
+const (
+	Queued   Status = "queued"
+	Running  Status = "running"
+	Done     Status = "done"
+	Failed   Status = "failed"
+	Canceled Status = "canceled"
+)
+
+var (
+	ErrQueueFull = errors.New("job queue is full")
+	ErrClosed    = errors.New("job manager is closed")
+)
+
+type Config struct {
+	Workers   int
+	QueueSize int
+	TTL       time.Duration
+}
+
+// Progress is what a running job reports: how much of the work is done and
+// the result so far.
+type Progress struct {
+	Completed int
+	Total     int
+	Partial   any
+}
+
+// RunFunc does the work of one job. It should report progress as it goes,
+// stop when ctx is done and return the final result.
+type RunFunc func(ctx context.Context, report func(Progress)) (any, error)
+
+// Job is a snapshot of a job's state, safe to hand out to callers. Owner is
+// whoever submitted the job; it is not shown to clients.
+type Job struct {
+	ID         string     `json:"id"`
+	Owner      string     `json:"-"`
+	Status     Status     `json:"status"`
+	Completed  int        `json:"completed"`
+	Total      int        `json:"total"`
+	Progress   float64    `json:"progress"`
+	Result     any        `json:"result,omitempty"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+type job struct {
+	Job
+	run      RunFunc
+	finished func(Job)
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+// Manager runs jobs on a fixed number of workers. Jobs wait in a bounded
+// queue and are forgotten TTL after they finish.
+type Manager struct {
+	config Config
+	queue  chan *job
+
+	mu     sync.Mutex
+	jobs   map[string]*job
+	closed bool
+
+	wg   sync.WaitGroup
+	stop chan struct{}
+}
+
+func NewManager(config Config) *Manager {
+	m := &Manager{
+		config: config,
+		queue:  make(chan *job, config.QueueSize),
+		jobs:   make(map[string]*job),
+		stop:   make(chan struct{}),
+	}
+
+	for i := 0; i < config.Workers; i++ {
+		m.wg.Add(1)
+		go m.worker()
+	}
+
+	m.wg.Add(1)
+	go m.janitor()
+
+	return m
+}
+
+// Submit queues a job for owner with the given amount of work, or returns
+// ErrQueueFull when the queue has no room left. If finished is not nil it
+// is called once with the final state of the job, however the job ends. It
+// runs with the manager locked, so it must not call back into the manager.
+func (m *Manager) Submit(owner string, total int, run RunFunc, finished func(Job)) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return Job{}, ErrClosed
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	j := &job{
+		Job: Job{
+			ID:        newID(),
+			Owner:     owner,
+			Status:    Queued,
+			Total:     total,
+			CreatedAt: time.Now(),
+		},
+		run:      run,
+		finished: finished,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+
+	select {
+	case m.queue <- j:
+	default:
+		cancel()
+		return Job{}, ErrQueueFull
+	}
+
+	m.jobs[j.ID] = j
+	jobsQueued.Inc()
+	slog.Debug("job queued", "job", j.ID, "total", total)
+	return j.Job, nil
+}
+
+func (m *Manager) Get(id string) (Job, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return j.Job, true
+}
+
+// Stats is how busy the manager is compared to what it can take.
+type Stats struct {
+	Queued    int  `json:"queued"`
+	QueueSize int  `json:"queueSize"`
+	Running   int  `json:"running"`
+	Workers   int  `json:"workers"`
+	Closed    bool `json:"closed"`
+}
+
+func (m *Manager) Stats() Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := Stats{QueueSize: m.config.QueueSize, Workers: m.config.Workers, Closed: m.closed}
+	for _, j := range m.jobs {
+		switch j.Status {
+		case Queued:
+			stats.Queued++
+		case Running:
+			stats.Running++
+		}
+	}
+	return stats
+}
+
+// Cancel stops a queued or running job. A running job keeps the partial
+// result it had reached.
+func (m *Manager) Cancel(id string) (Job, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	j, ok := m.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+
+	j.cancel()
+	if j.Status == Queued {
+		m.finish(j, Canceled, "")
+	}
+
+	return j.Job, true
+}
+
+// Close cancels every job and waits for the workers to stop. Queued jobs
+// finish as canceled straight away.
+func (m *Manager) Close() {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return
+	}
+	m.closed = true
+	for _, j := range m.jobs {
+		j.cancel()
+		if j.Status == Queued {
+			m.finish(j, Canceled, "")
+		}
+	}
+	close(m.queue)
+	close(m.stop)
+	m.mu.Unlock()
+
+	m.wg.Wait()
+}
+
+func (m *Manager) worker() {
+	defer m.wg.Done()
+
+	for j := range m.queue {
+		m.mu.Lock()
+		if j.Status != Queued || j.ctx.Err() != nil {
+			if j.Status == Queued {
+				m.finish(j, Canceled, "")
+			}
+			m.mu.Unlock()
+			continue
+		}
+		j.Status = Running
+		jobsQueued.Dec()
+		jobsRunning.Inc()
+		m.mu.Unlock()
+
+		result, err := j.run(j.ctx, func(progress Progress) {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			j.Completed = progress.Completed
+			if progress.Total > 0 {
+				j.Total = progress.Total
+			}
+			j.Result = progress.Partial
+			if j.Total > 0 {
+				j.Progress = float64(j.Completed) / float64(j.Total)
+			}
+		})
+
+		m.mu.Lock()
+		if result != nil {
+			j.Result = result
+		}
+		switch {
+		case j.ctx.Err() != nil:
+			m.finish(j, Canceled, "")
+		case err != nil:
+			m.finish(j, Failed, err.Error())
+		default:
+			j.Completed, j.Progress = j.Total, 1
+			m.finish(j, Done, "")
+		}
+		m.mu.Unlock()
+	}
+}
+
+func (m *Manager) finish(j *job, status Status, message string) {
+	switch j.Status {
+	case Queued:
+		jobsQueued.Dec()
+	case Running:
+		jobsRunning.Dec()
+	}
+	jobsTotal.With(string(status)).Inc()
+
+	now := time.Now()
+	j.Status = status
+	j.Error = message
+	j.FinishedAt = &now
+	j.cancel()
+
+	level := slog.LevelInfo
+	if status == Failed {
+		level = slog.LevelWarn
+	}
+	slog.Log(context.Background(), level, "job finished",
+		"job", j.ID,
+		"status", status,
+		"completed", j.Completed,
+		"duration", now.Sub(j.CreatedAt),
+		"error", message,
+	)
+
+	if j.finished != nil {
+		j.finished(j.Job)
+	}
+}
+
+func (m *Manager) janitor() {
+	defer m.wg.Done()
+
+	interval := max(m.config.TTL/2, time.Second)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			m.expire(time.Now())
+		}
+	}
+}
+
+func (m *Manager) expire(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, j := range m.jobs {
+		if j.FinishedAt != nil && now.Sub(*j.FinishedAt) > m.config.TTL {
+			delete(m.jobs, id)
+		}
+	}
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,152 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func waitFor(t *testing.T, m *Manager, id string, status Status) Job {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if job, ok := m.Get(id); ok && job.Status == status {
+			return job
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	job, _ := m.Get(id)
+	t.Fatalf("job %s is %q, want %q", id, job.Status, status)
+	return job
+}
+
+func TestManagerRunsJob(t *testing.T) {
+	m := NewManager(Config{Workers: 1, QueueSize: 1, TTL: time.Minute})
+	defer m.Close()
+
+	job, err := m.Submit("", 10, func(ctx context.Context, report func(Progress)) (any, error) {
+		report(Progress{Completed: 5, Partial: "half"})
+		return "all", nil
+	}, nil)
+	if err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+
+	done := waitFor(t, m, job.ID, Done)
+	if done.Result != "all" || done.Progress != 1 || done.FinishedAt == nil {
+		t.Errorf("unexpected finished job: %+v", done)
+	}
+}
+
+func TestManagerCancelKeepsPartialResult(t *testing.T) {
+	m := NewManager(Config{Workers: 1, QueueSize: 2, TTL: time.Minute})
+	defer m.Close()
+
+	started := make(chan struct{})
+	running, _ := m.Submit("", 100, func(ctx context.Context, report func(Progress)) (any, error) {
+		report(Progress{Completed: 40, Partial: 40})
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}, nil)
+	queued, _ := m.Submit("", 100, func(ctx context.Context, report func(Progress)) (any, error) {
+		t.Error("canceled job should never run")
+		return nil, nil
+	}, nil)
+
+	<-started
+	if job, _ := m.Get(running.ID); job.Status != Running || job.Progress != 0.4 {
+		t.Errorf("unexpected running job: %+v", job)
+	}
+
+	if job, ok := m.Cancel(queued.ID); !ok || job.Status != Canceled {
+		t.Errorf("queued job after cancel: %+v", job)
+	}
+	m.Cancel(running.ID)
+
+	job := waitFor(t, m, running.ID, Canceled)
+	if job.Result != 40 || job.Completed != 40 {
+		t.Errorf("partial result lost: %+v", job)
+	}
+}
+
+func TestManagerQueueFull(t *testing.T) {
+	m := NewManager(Config{Workers: 0, QueueSize: 1, TTL: time.Minute})
+	defer m.Close()
+
+	run := func(ctx context.Context, report func(Progress)) (any, error) { return nil, nil }
+
+	if _, err := m.Submit("", 1, run, nil); err != nil {
+		t.Fatalf("Submit() error = %v", err)
+	}
+	if _, err := m.Submit("", 1, run, nil); !errors.Is(err, ErrQueueFull) {
+		t.Errorf("Submit() error = %v, want %v", err, ErrQueueFull)
+	}
+}
+
+func TestManagerExpiresFinishedJobs(t *testing.T) {
+	m := NewManager(Config{Workers: 1, QueueSize: 1, TTL: time.Minute})
+	defer m.Close()
+
+	job, _ := m.Submit("", 1, func(ctx context.Context, report func(Progress)) (any, error) {
+		return nil, errors.New("boom")
+	}, nil)
+	failed := waitFor(t, m, job.ID, Failed)
+	if failed.Error != "boom" {
+		t.Errorf("error = %q", failed.Error)
+	}
+
+	m.expire(time.Now())
+	if _, ok := m.Get(job.ID); !ok {
+		t.Fatalf("job expired before its TTL")
+	}
+
+	m.expire(time.Now().Add(2 * time.Minute))
+	if _, ok := m.Get(job.ID); ok {
+		t.Errorf("job still present after its TTL")
+	}
+}
+
+func TestManagerReportsEveryFinishedJob(t *testing.T) {
+	m := NewManager(Config{Workers: 1, QueueSize: 3, TTL: time.Minute})
+
+	finished := make(chan Job, 3)
+	started := make(chan struct{})
+
+	running, _ := m.Submit("", 100, func(ctx context.Context, report func(Progress)) (any, error) {
+		report(Progress{Completed: 40})
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}, func(job Job) { finished <- job })
+	canceled, _ := m.Submit("", 100, func(ctx context.Context, report func(Progress)) (any, error) {
+		return nil, nil
+	}, func(job Job) { finished <- job })
+	closed, _ := m.Submit("", 100, func(ctx context.Context, report func(Progress)) (any, error) {
+		return nil, nil
+	}, func(job Job) { finished <- job })
+
+	<-started
+	m.Cancel(canceled.ID)
+	m.Close()
+	close(finished)
+
+	want := map[string]int{running.ID: 40, canceled.ID: 0, closed.ID: 0}
+	for job := range finished {
+		completed, ok := want[job.ID]
+		if !ok {
+			t.Errorf("job %s reported twice", job.ID)
+			continue
+		}
+		if job.Status != Canceled || job.Completed != completed {
+			t.Errorf("job %s finished as %+v", job.ID, job)
+		}
+		delete(want, job.ID)
+	}
+	for id := range want {
+		t.Errorf("job %s never reported", id)
+	}
+}
@@ -0,0 +1,110 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+)
+
+// Keys that carry card data. Their values are replaced when redaction is
+// on, wherever they appear.
+const (
+	PlayerCardsKey    = "playerCards"
+	OpponentCardsKey  = "opponentCards"
+	CommunityCardsKey = "communityCards"
+	DeadCardsKey      = "deadCards"
+	HoleCardsKey      = "holeCards"
+)
+
+var cardKeys = map[string]bool{
+	PlayerCardsKey:    true,
+	OpponentCardsKey:  true,
+	CommunityCardsKey: true,
+	DeadCardsKey:      true,
+	HoleCardsKey:      true,
+}
+
+const redacted = "[redacted]"
+
+type Options struct {
+	Level       string
+	RedactCards bool
+}
+
+// New returns a JSON logger. Records logged with a context carry the
+// request ID stored in it by WithRequestID.
+func New(w io.Writer, options Options) (*slog.Logger, error) {
+	level, err := ParseLevel(options.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level: level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if options.RedactCards && cardKeys[a.Key] {
+				return slog.String(a.Key, redacted)
+			}
+			return a
+		},
+	})
+
+	return slog.New(contextHandler{handler}), nil
+}
+
+func ParseLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("unknown log level %q", level)
+}
+
+// Cards logs cards in their short form, "Ah Kd".
+func Cards(key string, cards []poker.Card) slog.Attr {
+	codes := make([]string, len(cards))
+	for i, card := range cards {
+		codes[i] = card.Code()
+	}
+	return slog.String(key, strings.Join(codes, " "))
+}
+
+type requestIDKey struct{}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		record.AddAttrs(slog.String("requestId", id))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
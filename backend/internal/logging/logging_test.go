@@ -0,0 +1,87 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+)
+
+func TestNew(t *testing.T) {
+	cards := []poker.Card{{Rank: poker.Ace, Suit: poker.Hearts}, {Rank: poker.King, Suit: poker.Diamonds}}
+
+	tests := []struct {
+		name      string
+		options   Options
+		level     slog.Level
+		wantLog   bool
+		wantCards string
+	}{
+		{"Redacted", Options{Level: "info", RedactCards: true}, slog.LevelInfo, true, "[redacted]"},
+		{"Shown", Options{Level: "debug"}, slog.LevelDebug, true, "Ah Kd"},
+		{"Below level", Options{Level: "warn"}, slog.LevelInfo, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger, err := New(&buf, tt.options)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			ctx := WithRequestID(context.Background(), "abc123")
+			logger.Log(ctx, tt.level, "simulation request", Cards(PlayerCardsKey, cards), "iterations", 100)
+
+			if !tt.wantLog {
+				if buf.Len() != 0 {
+					t.Errorf("got %q, want nothing logged", buf.String())
+				}
+				return
+			}
+
+			var record map[string]any
+			if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+				t.Fatalf("log line %q is not JSON: %v", buf.String(), err)
+			}
+			if record[PlayerCardsKey] != tt.wantCards {
+				t.Errorf("%s = %v, want %q", PlayerCardsKey, record[PlayerCardsKey], tt.wantCards)
+			}
+			if record["requestId"] != "abc123" {
+				t.Errorf("requestId = %v, want abc123", record["requestId"])
+			}
+			if record["iterations"] != float64(100) {
+				t.Errorf("iterations = %v, want 100", record["iterations"])
+			}
+		})
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		level   string
+		want    slog.Level
+		wantErr bool
+	}{
+		{"debug", slog.LevelDebug, false},
+		{"INFO", slog.LevelInfo, false},
+		{"", slog.LevelInfo, false},
+		{"warn", slog.LevelWarn, false},
+		{"error", slog.LevelError, false},
+		{"verbose", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseLevel(tt.level)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLevel(%q) error = %v, wantErr %v", tt.level, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseLevel(%q) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
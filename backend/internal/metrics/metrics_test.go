@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWriteTo(t *testing.T) {
+	registry := NewRegistry()
+
+	requests := registry.NewCounterVec("requests_total", "Requests served.", "route", "status")
+	requests.With("/b", "200").Inc()
+	requests.With("/a", "500").Add(2)
+	requests.With("/a", "500").Add(-1)
+
+	workers := registry.NewGauge("workers", "Workers running.")
+	workers.Inc()
+	workers.Inc()
+	workers.Dec()
+
+	registry.NewGaugeFunc("ratio", "A ratio.", func() float64 { return 0.25 })
+
+	latency := registry.NewHistogram("latency_seconds", "Latency.", []float64{0.1, 1})
+	latency.Observe(0.05)
+	latency.Observe(0.5)
+	latency.Observe(3)
+
+	registry.NewCounterVec("escaped_total", "Line one\nline two.", "value").With(`say "hi"\`).Inc()
+
+	var b strings.Builder
+	registry.WriteTo(&b)
+
+	want := `# HELP requests_total Requests served.
+# TYPE requests_total counter
+requests_total{route="/a",status="500"} 2
+requests_total{route="/b",status="200"} 1
+# HELP workers Workers running.
+# TYPE workers gauge
+workers 1
+# HELP ratio A ratio.
+# TYPE ratio gauge
+ratio 0.25
+# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{le="0.1"} 1
+latency_seconds_bucket{le="1"} 2
+latency_seconds_bucket{le="+Inf"} 3
+latency_seconds_sum 3.55
+latency_seconds_count 3
+# HELP escaped_total Line one\nline two.
+# TYPE escaped_total counter
+escaped_total{value="say \"hi\"\\"} 1
+`
+
+	if got := b.String(); got != want {
+		t.Errorf("WriteTo() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRegisterTwice(t *testing.T) {
+	registry := NewRegistry()
+	registry.NewCounter("requests_total", "Requests served.")
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("registering a name twice did not panic")
+		}
+	}()
+	registry.NewGauge("requests_total", "Requests served.")
+}
+
+func TestHandler(t *testing.T) {
+	registry := NewRegistry()
+	registry.NewCounter("requests_total", "Requests served.").Inc()
+
+	tests := []struct {
+		method     string
+		wantStatus int
+	}{
+		{http.MethodGet, http.StatusOK},
+		{http.MethodPost, http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		Handler(registry)(w, httptest.NewRequest(tt.method, "/metrics", nil))
+
+		if w.Code != tt.wantStatus {
+			t.Errorf("%s: status = %d, want %d", tt.method, w.Code, tt.wantStatus)
+		}
+		if tt.wantStatus == http.StatusOK {
+			if !strings.HasPrefix(w.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+				t.Errorf("Content-Type = %q", w.Header().Get("Content-Type"))
+			}
+			if !strings.Contains(w.Body.String(), "requests_total 1\n") {
+				t.Errorf("body = %q", w.Body.String())
+			}
+		}
+	}
+}
@@ -47,3 +47,35 @@ func (suit Suit) String() string {
 func (card Card) String() string {
 	return fmt.Sprintf("%s%s", card.Rank.String(), card.Suit.String())
 }
+
+var rankCodes = map[byte]Rank{'2': Two, '3': Three, '4': Four, '5': Five, '6': Six, '7': Seven, '8': Eight, '9': Nine, 'T': Ten, 'J': Jack, 'Q': Queen, 'K': King, 'A': Ace}
+var suitCodes = map[byte]Suit{'c': Clubs, 'd': Diamonds, 'h': Hearts, 's': Spades}
+
+// ParseCard reads the two character notation used by hand histories, such
+// as "Ah" or "Tc".
+func ParseCard(code string) (Card, error) {
+	if len(code) != 2 {
+		return Card{}, fmt.Errorf("invalid card %q", code)
+	}
+
+	rank, rankOk := rankCodes[code[0]]
+	suit, suitOk := suitCodes[code[1]]
+	if !rankOk || !suitOk {
+		return Card{}, fmt.Errorf("invalid card %q", code)
+	}
+
+	return Card{Rank: rank, Suit: suit}, nil
+}
+
+func (card Card) Code() string {
+	for code, rank := range rankCodes {
+		if rank == card.Rank {
+			for suitCode, suit := range suitCodes {
+				if suit == card.Suit {
+					return string([]byte{code, suitCode})
+				}
+			}
+		}
+	}
+	return "??"
+}
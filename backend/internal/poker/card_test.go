@@ -104,3 +104,33 @@ func TestHandString(t *testing.T) {
 		})
 	}
 }
+
+func TestParseCard(t *testing.T) {
+	tests := []struct {
+		code    string
+		want    Card
+		wantErr bool
+	}{
+		{code: "Ah", want: Card{Ace, Hearts}},
+		{code: "Tc", want: Card{Ten, Clubs}},
+		{code: "2s", want: Card{Two, Spades}},
+		{code: "10h", wantErr: true},
+		{code: "Ax", wantErr: true},
+		{code: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			got, err := ParseCard(tt.code)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseCard() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseCard() = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr && got.Code() != tt.code {
+				t.Errorf("Card.Code() = %v, want %v", got.Code(), tt.code)
+			}
+		})
+	}
+}
@@ -25,6 +25,12 @@ func (deck *Deck) Shuffle() {
 	})
 }
 
+func (deck *Deck) ShuffleWith(rng *rand.Rand) {
+	rng.Shuffle(len(deck.Cards), func(i, j int) {
+		deck.Cards[i], deck.Cards[j] = deck.Cards[j], deck.Cards[i]
+	})
+}
+
 func (deck *Deck) Draw(amount int) []Card {
 	drawnCards := make([]Card, amount)
 	copy(drawnCards, deck.Cards[:amount])
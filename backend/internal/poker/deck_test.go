@@ -1,6 +1,7 @@
 package poker
 
 import (
+	"math/rand"
 	"testing"
 )
 
@@ -54,6 +55,19 @@ func TestShuffle(t *testing.T) {
 	}
 }
 
+func TestShuffleWithIsDeterministic(t *testing.T) {
+	deck1, deck2 := NewDeck(), NewDeck()
+
+	deck1.ShuffleWith(rand.New(rand.NewSource(42)))
+	deck2.ShuffleWith(rand.New(rand.NewSource(42)))
+
+	for i := range deck1.Cards {
+		if deck1.Cards[i] != deck2.Cards[i] {
+			t.Fatalf("decks differ at position %d: %v != %v", i, deck1.Cards[i], deck2.Cards[i])
+		}
+	}
+}
+
 func TestDraw(t *testing.T) {
 	deck := NewDeck()
 	card := deck.Draw(1)
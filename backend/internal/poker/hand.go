@@ -123,9 +123,11 @@ func (hand *Hand) EvaluateHandStrenght(communityCards []Card) *HandRank {
 		return result
 	}
 
-	highCards := append(hand.Cards, communityCards...)
+	highCards := make([]Card, len(hand.Cards)+len(communityCards))
+	copy(highCards, hand.Cards)
+	copy(highCards[len(hand.Cards):], communityCards)
 	sortCardsByRank(&highCards)
-	highCards = highCards[:5]
+	highCards = highCards[:min(5, len(highCards))]
 
 	return &HandRank{
 		Type:     HighCard,
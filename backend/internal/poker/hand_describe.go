@@ -0,0 +1,81 @@
+package poker
+
+import (
+	"fmt"
+)
+
+var rankNames = map[Rank][2]string{
+	Two:   {"Two", "Twos"},
+	Three: {"Three", "Threes"},
+	Four:  {"Four", "Fours"},
+	Five:  {"Five", "Fives"},
+	Six:   {"Six", "Sixes"},
+	Seven: {"Seven", "Sevens"},
+	Eight: {"Eight", "Eights"},
+	Nine:  {"Nine", "Nines"},
+	Ten:   {"Ten", "Tens"},
+	Jack:  {"Jack", "Jacks"},
+	Queen: {"Queen", "Queens"},
+	King:  {"King", "Kings"},
+	Ace:   {"Ace", "Aces"},
+}
+
+func (rank Rank) Name() string {
+	return rankNames[rank][0]
+}
+
+func (rank Rank) PluralName() string {
+	return rankNames[rank][1]
+}
+
+func withArticle(rank Rank) string {
+	if rank == Ace || rank == Eight {
+		return "an " + rank.Name()
+	}
+	return "a " + rank.Name()
+}
+
+func withKicker(description string, ranks []Rank, kickerIndex int) string {
+	if kickerIndex >= len(ranks) {
+		return description
+	}
+	return fmt.Sprintf("%s with %s kicker", description, withArticle(ranks[kickerIndex]))
+}
+
+func (h HandRank) Describe() string {
+	if len(h.BestHand) == 0 {
+		return h.Type.String()
+	}
+
+	ranks := significantRanks(h.Type, h.BestHand)
+	wheel := ranks[len(ranks)-1] == 1
+
+	switch h.Type {
+	case RoyalFlush:
+		return "Royal Flush"
+	case StraightFlush:
+		if wheel {
+			return "Steel wheel straight flush (Five-high)"
+		}
+		return fmt.Sprintf("%s-high straight flush", ranks[0].Name())
+	case FourOfAKind:
+		return withKicker(fmt.Sprintf("Four of a Kind, %s", ranks[0].PluralName()), ranks, 4)
+	case FullHouse:
+		return fmt.Sprintf("Full House, %s full of %s", ranks[0].PluralName(), ranks[3].PluralName())
+	case Flush:
+		return fmt.Sprintf("%s-high flush", ranks[0].Name())
+	case Straight:
+		if wheel {
+			return "Wheel straight (Five-high)"
+		}
+		return fmt.Sprintf("%s-high straight", ranks[0].Name())
+	case ThreeOfAKind:
+		return withKicker(fmt.Sprintf("Three of a Kind, %s", ranks[0].PluralName()), ranks, 3)
+	case TwoPair:
+		return withKicker(fmt.Sprintf("Two Pair, %s and %s", ranks[0].PluralName(), ranks[2].PluralName()), ranks, 4)
+	case Pair:
+		return withKicker(fmt.Sprintf("Pair of %s", ranks[0].PluralName()), ranks, 2)
+	default:
+		return fmt.Sprintf("High Card, %s", ranks[0].Name())
+	}
+}
@@ -0,0 +1,89 @@
+package poker
+
+import "testing"
+
+func TestHandRankDescribe(t *testing.T) {
+	tests := []struct {
+		name     string
+		rank     HandRank
+		expected string
+	}{
+		{
+			name:     "Royal flush",
+			rank:     HandRank{Type: RoyalFlush, BestHand: []Card{{Ace, Spades}, {King, Spades}, {Queen, Spades}, {Jack, Spades}, {Ten, Spades}}},
+			expected: "Royal Flush",
+		},
+		{
+			name:     "Straight flush",
+			rank:     HandRank{Type: StraightFlush, BestHand: []Card{{Five, Hearts}, {Six, Hearts}, {Seven, Hearts}, {Eight, Hearts}, {Nine, Hearts}}},
+			expected: "Nine-high straight flush",
+		},
+		{
+			name:     "Four of a kind",
+			rank:     HandRank{Type: FourOfAKind, BestHand: []Card{{Jack, Spades}, {Jack, Hearts}, {Jack, Clubs}, {Jack, Diamonds}, {Eight, Spades}}},
+			expected: "Four of a Kind, Jacks with an Eight kicker",
+		},
+		{
+			name:     "Full house",
+			rank:     HandRank{Type: FullHouse, BestHand: []Card{{Ten, Spades}, {Ten, Hearts}, {Ten, Clubs}, {Three, Diamonds}, {Three, Spades}}},
+			expected: "Full House, Tens full of Threes",
+		},
+		{
+			name:     "Flush",
+			rank:     HandRank{Type: Flush, BestHand: []Card{{Ace, Clubs}, {Nine, Clubs}, {Seven, Clubs}, {Four, Clubs}, {Two, Clubs}}},
+			expected: "Ace-high flush",
+		},
+		{
+			name:     "Wheel straight",
+			rank:     HandRank{Type: Straight, BestHand: []Card{{Ace, Clubs}, {Two, Hearts}, {Three, Clubs}, {Four, Spades}, {Five, Clubs}}},
+			expected: "Wheel straight (Five-high)",
+		},
+		{
+			name:     "Three of a kind",
+			rank:     HandRank{Type: ThreeOfAKind, BestHand: []Card{{Six, Clubs}, {Six, Hearts}, {Six, Spades}, {King, Spades}, {Five, Clubs}}},
+			expected: "Three of a Kind, Sixes with a King kicker",
+		},
+		{
+			name:     "Two pair",
+			rank:     HandRank{Type: TwoPair, BestHand: []Card{{King, Clubs}, {King, Hearts}, {Four, Clubs}, {Four, Spades}, {Jack, Clubs}}},
+			expected: "Two Pair, Kings and Fours with a Jack kicker",
+		},
+		{
+			name:     "Pair without kickers",
+			rank:     HandRank{Type: Pair, BestHand: []Card{{Queen, Clubs}, {Queen, Hearts}}},
+			expected: "Pair of Queens",
+		},
+		{
+			name:     "High card",
+			rank:     HandRank{Type: HighCard, BestHand: []Card{{Ace, Clubs}, {Ten, Hearts}, {Eight, Clubs}, {Four, Spades}, {Two, Clubs}}},
+			expected: "High Card, Ace",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rank.Describe(); got != tt.expected {
+				t.Errorf("HandRank.Describe() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDescribeWithoutBoard(t *testing.T) {
+	tests := []struct {
+		name     string
+		hand     Hand
+		expected string
+	}{
+		{"Unpaired hole cards", NewHand(Card{Ace, Clubs}, Card{Ten, Hearts}), "High Card, Ace"},
+		{"Pocket pair", NewHand(Card{Queen, Clubs}, Card{Queen, Hearts}), "Pair of Queens"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hand.EvaluateHandStrenght(nil).Describe(); got != tt.expected {
+				t.Errorf("Describe() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package poker
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+)
+
+const DistinctHandValues = 7462
+
+var (
+	handIndexOnce sync.Once
+	handIndex     map[int]int
+)
+
+// Score packs the hand category and its ranks, in order of significance, into
+// a single integer. A higher score is a stronger hand and equal scores tie.
+func (h HandRank) Score() int {
+	handType := h.Type
+	if handType == RoyalFlush {
+		handType = StraightFlush
+	}
+
+	return handScore(handType, significantRanks(handType, h.BestHand))
+}
+
+func (h HandRank) Compare(other HandRank) int {
+	return cmp.Compare(h.Score(), other.Score())
+}
+
+// Index returns the position of the hand among all 7462 distinct five card
+// hand values, where 1 is a royal flush and 7462 is 7-5-4-3-2 offsuit.
+// Hands with fewer than five cards have no index and return 0.
+func (h HandRank) Index() int {
+	handIndexOnce.Do(buildHandIndex)
+
+	return handIndex[h.Score()]
+}
+
+func handScore(handType HandRankType, ranks []Rank) int {
+	score := int(handType)
+	for i := 0; i < 5; i++ {
+		score *= 15
+		if i < len(ranks) {
+			score += int(ranks[i])
+		}
+	}
+	return score
+}
+
+func significantRanks(handType HandRankType, cards []Card) []Rank {
+	counts := make(map[Rank]int)
+	for _, card := range cards {
+		counts[card.Rank]++
+	}
+
+	var ranks []Rank
+	for rank := range counts {
+		ranks = append(ranks, rank)
+	}
+
+	slices.SortFunc(ranks, func(a, b Rank) int {
+		if counts[a] != counts[b] {
+			return counts[b] - counts[a]
+		}
+		return int(b) - int(a)
+	})
+
+	if (handType == Straight || handType == StraightFlush) &&
+		slices.Equal(ranks, []Rank{Ace, Five, Four, Three, Two}) {
+		return []Rank{Five, Four, Three, Two, 1}
+	}
+
+	var ordered []Rank
+	for _, rank := range ranks {
+		for i := 0; i < counts[rank]; i++ {
+			ordered = append(ordered, rank)
+		}
+	}
+	return ordered
+}
+
+func buildHandIndex() {
+	var scores []int
+
+	ranks := make([]Rank, 0, 5)
+	var collect func(start Rank)
+	collect = func(start Rank) {
+		if len(ranks) == 5 {
+			scores = append(scores, scoresForRanks(ranks)...)
+			return
+		}
+		for rank := start; rank <= Ace; rank++ {
+			ranks = append(ranks, rank)
+			collect(rank)
+			ranks = ranks[:len(ranks)-1]
+		}
+	}
+	collect(Two)
+
+	slices.Sort(scores)
+	slices.Reverse(scores)
+
+	handIndex = make(map[int]int, len(scores))
+	for i, score := range scores {
+		handIndex[score] = i + 1
+	}
+}
+
+// scoresForRanks returns the score of every distinct hand that can be made
+// from the given rank multiset: one value, or two when the ranks are all
+// different and the hand may also be suited.
+func scoresForRanks(ranks []Rank) []int {
+	counts := make(map[Rank]int)
+	for _, rank := range ranks {
+		counts[rank]++
+	}
+
+	var shape []int
+	for _, count := range counts {
+		shape = append(shape, count)
+	}
+	slices.Sort(shape)
+	slices.Reverse(shape)
+
+	cards := make([]Card, len(ranks))
+	for i, rank := range ranks {
+		cards[i] = Card{Rank: rank}
+	}
+
+	var handType HandRankType
+	switch {
+	case shape[0] == 5:
+		return nil
+	case shape[0] == 4:
+		handType = FourOfAKind
+	case shape[0] == 3 && shape[1] == 2:
+		handType = FullHouse
+	case shape[0] == 3:
+		handType = ThreeOfAKind
+	case shape[0] == 2 && shape[1] == 2:
+		handType = TwoPair
+	case shape[0] == 2:
+		handType = Pair
+	default:
+		straight := ranks[4]-ranks[0] == 4 || slices.Equal(ranks, []Rank{Two, Three, Four, Five, Ace})
+
+		offsuit, suited := HighCard, Flush
+		if straight {
+			offsuit, suited = Straight, StraightFlush
+		}
+
+		return []int{
+			handScore(offsuit, significantRanks(offsuit, cards)),
+			handScore(suited, significantRanks(suited, cards)),
+		}
+	}
+
+	return []int{handScore(handType, significantRanks(handType, cards))}
+}
@@ -0,0 +1,129 @@
+package poker
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestHandRankIndex(t *testing.T) {
+	tests := []struct {
+		name string
+		rank HandRank
+		want int
+	}{
+		{
+			name: "Royal flush is the best hand",
+			rank: HandRank{Type: RoyalFlush, BestHand: []Card{{Ace, Spades}, {King, Spades}, {Queen, Spades}, {Jack, Spades}, {Ten, Spades}}},
+			want: 1,
+		},
+		{
+			name: "Steel wheel is the worst straight flush",
+			rank: HandRank{Type: StraightFlush, BestHand: []Card{{Ace, Hearts}, {Two, Hearts}, {Three, Hearts}, {Four, Hearts}, {Five, Hearts}}},
+			want: 10,
+		},
+		{
+			name: "Four aces with a king",
+			rank: HandRank{Type: FourOfAKind, BestHand: []Card{{Ace, Spades}, {Ace, Hearts}, {Ace, Clubs}, {Ace, Diamonds}, {King, Spades}}},
+			want: 11,
+		},
+		{
+			name: "Ace high flush",
+			rank: HandRank{Type: Flush, BestHand: []Card{{Ace, Clubs}, {King, Clubs}, {Queen, Clubs}, {Jack, Clubs}, {Nine, Clubs}}},
+			want: 323,
+		},
+		{
+			name: "Broadway straight",
+			rank: HandRank{Type: Straight, BestHand: []Card{{Ace, Clubs}, {King, Hearts}, {Queen, Clubs}, {Jack, Clubs}, {Ten, Clubs}}},
+			want: 1600,
+		},
+		{
+			name: "Seven high is the worst hand",
+			rank: HandRank{Type: HighCard, BestHand: []Card{{Seven, Clubs}, {Five, Hearts}, {Four, Clubs}, {Three, Clubs}, {Two, Spades}}},
+			want: DistinctHandValues,
+		},
+		{
+			name: "Incomplete hand has no index",
+			rank: HandRank{Type: Pair, BestHand: []Card{{Seven, Clubs}, {Seven, Hearts}}},
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rank.Index(); got != tt.want {
+				t.Errorf("HandRank.Index() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandIndexCoversAllValues(t *testing.T) {
+	handIndexOnce.Do(buildHandIndex)
+
+	if len(handIndex) != DistinctHandValues {
+		t.Errorf("expected %d distinct hand values, got %d", DistinctHandValues, len(handIndex))
+	}
+}
+
+func TestCompareHandsKickers(t *testing.T) {
+	tests := []struct {
+		name           string
+		hand1          Hand
+		hand2          Hand
+		communityCards []Card
+		want           Result
+	}{
+		{
+			name:           "Higher pair beats higher kicker",
+			hand1:          NewHand(Card{Two, Spades}, Card{Ace, Hearts}),
+			hand2:          NewHand(Card{Three, Spades}, Card{King, Hearts}),
+			communityCards: []Card{{Two, Clubs}, {Three, Clubs}, {Nine, Diamonds}, {Jack, Hearts}, {Queen, Spades}},
+			want:           Lose,
+		},
+		{
+			name:           "Trips decide a full house",
+			hand1:          NewHand(Card{Three, Spades}, Card{Ace, Spades}),
+			hand2:          NewHand(Card{King, Spades}, Card{Two, Spades}),
+			communityCards: []Card{{Three, Clubs}, {Three, Diamonds}, {Ace, Diamonds}, {King, Clubs}, {King, Diamonds}},
+			want:           Lose,
+		},
+		{
+			name:           "Wheel loses to six high straight",
+			hand1:          NewHand(Card{Ace, Spades}, Card{Two, Hearts}),
+			hand2:          NewHand(Card{Six, Spades}, Card{Two, Diamonds}),
+			communityCards: []Card{{Three, Clubs}, {Four, Clubs}, {Five, Diamonds}, {King, Hearts}, {King, Spades}},
+			want:           Lose,
+		},
+		{
+			name:           "Board plays",
+			hand1:          NewHand(Card{Two, Spades}, Card{Three, Hearts}),
+			hand2:          NewHand(Card{Two, Diamonds}, Card{Four, Hearts}),
+			communityCards: []Card{{Ace, Clubs}, {King, Clubs}, {Queen, Diamonds}, {Jack, Hearts}, {Ten, Spades}},
+			want:           Tie,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _, _ := CompareHands(tt.hand1, tt.hand2, tt.communityCards); got != tt.want {
+				t.Errorf("CompareHands() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandRankCompareSorts(t *testing.T) {
+	ranks := []HandRank{
+		{Type: Pair, BestHand: []Card{{Nine, Spades}, {Nine, Hearts}, {Ace, Clubs}, {Four, Clubs}, {Two, Clubs}}},
+		{Type: Flush, BestHand: []Card{{Ten, Clubs}, {Eight, Clubs}, {Six, Clubs}, {Four, Clubs}, {Two, Clubs}}},
+		{Type: Pair, BestHand: []Card{{Nine, Clubs}, {Nine, Diamonds}, {Ace, Spades}, {Five, Clubs}, {Two, Hearts}}},
+	}
+
+	slices.SortFunc(ranks, func(a, b HandRank) int {
+		return b.Compare(a)
+	})
+
+	if ranks[0].Type != Flush || ranks[1].BestHand[3].Rank != Five {
+		t.Errorf("unexpected order: %v", ranks)
+	}
+}
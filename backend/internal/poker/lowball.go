@@ -0,0 +1,234 @@
+package poker
+
+import (
+	"errors"
+	"sort"
+)
+
+type LowballGame int
+
+const (
+	DeuceToSeven LowballGame = iota
+	AceToFive
+)
+
+func (g LowballGame) String() string {
+	switch g {
+	case DeuceToSeven:
+		return "2-7 Lowball"
+	case AceToFive:
+		return "A-5 Lowball"
+	default:
+		return "Unknown Game"
+	}
+}
+
+type LowballRank struct {
+	Game     LowballGame
+	Type     LowballRankType
+	BestHand []Card
+}
+
+// LowballRankType categories are ordered from best to worst, so a lower
+// value always wins. A-5 never produces straights or flushes.
+type LowballRankType int
+
+const (
+	LowballNoPair LowballRankType = iota
+	LowballPair
+	LowballTwoPair
+	LowballThreeOfAKind
+	LowballStraight
+	LowballFlush
+	LowballFullHouse
+	LowballFourOfAKind
+	LowballStraightFlush
+)
+
+func (l LowballRankType) String() string {
+	lowballRankStrings := map[LowballRankType]string{
+		LowballNoPair:        "No Pair",
+		LowballPair:          "Pair",
+		LowballTwoPair:       "Two Pair",
+		LowballThreeOfAKind:  "Three of a Kind",
+		LowballStraight:      "Straight",
+		LowballFlush:         "Flush",
+		LowballFullHouse:     "Full House",
+		LowballFourOfAKind:   "Four of a Kind",
+		LowballStraightFlush: "Straight Flush",
+	}
+
+	if str, exists := lowballRankStrings[l]; exists {
+		return str
+	}
+	return "Unknown Hand"
+}
+
+func (g LowballGame) rankValue(rank Rank) int {
+	if g == AceToFive && rank == Ace {
+		return 1
+	}
+	return int(rank)
+}
+
+func (hand *Hand) EvaluateLowball(game LowballGame) *LowballRank {
+	if len(hand.Cards) < 5 {
+		return nil
+	}
+
+	var best *LowballRank
+	combination := make([]Card, 5)
+
+	var choose func(start, depth int)
+	choose = func(start, depth int) {
+		if depth == 5 {
+			rank := evaluateLowballFive(combination, game)
+			if best == nil || compareLowballRanks(rank, best) == Win {
+				best = rank
+			}
+			return
+		}
+		for i := start; i <= len(hand.Cards)-(5-depth); i++ {
+			combination[depth] = hand.Cards[i]
+			choose(i+1, depth+1)
+		}
+	}
+	choose(0, 0)
+
+	return best
+}
+
+func evaluateLowballFive(cards []Card, game LowballGame) *LowballRank {
+	bestHand := make([]Card, 5)
+	copy(bestHand, cards)
+
+	counts := make(map[Rank]int)
+	suits := make(map[Suit]int)
+	for _, card := range bestHand {
+		counts[card.Rank]++
+		suits[card.Suit]++
+	}
+
+	sort.Slice(bestHand, func(i, j int) bool {
+		ci, cj := counts[bestHand[i].Rank], counts[bestHand[j].Rank]
+		if ci != cj {
+			return ci > cj
+		}
+		return game.rankValue(bestHand[i].Rank) > game.rankValue(bestHand[j].Rank)
+	})
+
+	rank := &LowballRank{Game: game, BestHand: bestHand}
+
+	switch counts[bestHand[0].Rank] {
+	case 4:
+		rank.Type = LowballFourOfAKind
+	case 3:
+		if counts[bestHand[3].Rank] == 2 {
+			rank.Type = LowballFullHouse
+		} else {
+			rank.Type = LowballThreeOfAKind
+		}
+	case 2:
+		if counts[bestHand[2].Rank] == 2 {
+			rank.Type = LowballTwoPair
+		} else {
+			rank.Type = LowballPair
+		}
+	default:
+		rank.Type = LowballNoPair
+		if game == DeuceToSeven {
+			straight := int(bestHand[0].Rank)-int(bestHand[4].Rank) == 4
+			flush := len(suits) == 1
+
+			switch {
+			case straight && flush:
+				rank.Type = LowballStraightFlush
+			case flush:
+				rank.Type = LowballFlush
+			case straight:
+				rank.Type = LowballStraight
+			}
+		}
+	}
+
+	return rank
+}
+
+var ErrTooFewLowballCards = errors.New("lowball hands need at least 5 cards")
+
+// CompareLowballHands returns the result for hand1 together with the winning
+// and losing ranks. Both hands must have at least 5 cards.
+func CompareLowballHands(hand1 Hand, hand2 Hand, game LowballGame) (Result, LowballRank, LowballRank, error) {
+	result1 := hand1.EvaluateLowball(game)
+	result2 := hand2.EvaluateLowball(game)
+	if result1 == nil || result2 == nil {
+		return Tie, LowballRank{}, LowballRank{}, ErrTooFewLowballCards
+	}
+
+	switch compareLowballRanks(result1, result2) {
+	case Win:
+		return Win, *result1, *result2, nil
+	case Lose:
+		return Lose, *result2, *result1, nil
+	default:
+		return Tie, *result1, *result2, nil
+	}
+}
+
+func compareLowballRanks(result1 *LowballRank, result2 *LowballRank) Result {
+	if result1.Type < result2.Type {
+		return Win
+	} else if result1.Type > result2.Type {
+		return Lose
+	}
+
+	for i := 0; i < len(result1.BestHand); i++ {
+		value1 := result1.Game.rankValue(result1.BestHand[i].Rank)
+		value2 := result2.Game.rankValue(result2.BestHand[i].Rank)
+
+		if value1 < value2 {
+			return Win
+		} else if value1 > value2 {
+			return Lose
+		}
+	}
+
+	return Tie
+}
+
+// LowballDiscards picks the n cards a drawing player should throw away:
+// extra cards of a paired rank first, then the highest cards for the game.
+func (hand *Hand) LowballDiscards(n int, game LowballGame) []Card {
+	n = min(n, len(hand.Cards))
+
+	cards := make([]Card, len(hand.Cards))
+	copy(cards, hand.Cards)
+
+	seen := make(map[Rank]bool)
+	duplicate := make([]bool, len(cards))
+
+	sort.Slice(cards, func(i, j int) bool {
+		return game.rankValue(cards[i].Rank) < game.rankValue(cards[j].Rank)
+	})
+
+	for i, card := range cards {
+		if seen[card.Rank] {
+			duplicate[i] = true
+		}
+		seen[card.Rank] = true
+	}
+
+	var discards []Card
+	for i := len(cards) - 1; i >= 0 && len(discards) < n; i-- {
+		if duplicate[i] {
+			discards = append(discards, cards[i])
+		}
+	}
+	for i := len(cards) - 1; i >= 0 && len(discards) < n; i-- {
+		if !duplicate[i] {
+			discards = append(discards, cards[i])
+		}
+	}
+
+	return discards
+}
@@ -0,0 +1,171 @@
+package poker
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEvaluateLowball(t *testing.T) {
+	tests := []struct {
+		name string
+		hand Hand
+		game LowballGame
+		want LowballRankType
+	}{
+		{
+			name: "2-7 number one",
+			hand: NewHand(Card{Seven, Spades}, Card{Five, Hearts}, Card{Four, Clubs}, Card{Three, Diamonds}, Card{Two, Spades}),
+			game: DeuceToSeven,
+			want: LowballNoPair,
+		},
+		{
+			name: "2-7 wheel is not a straight",
+			hand: NewHand(Card{Ace, Spades}, Card{Five, Hearts}, Card{Four, Clubs}, Card{Three, Diamonds}, Card{Two, Spades}),
+			game: DeuceToSeven,
+			want: LowballNoPair,
+		},
+		{
+			name: "2-7 straight counts",
+			hand: NewHand(Card{Six, Spades}, Card{Five, Hearts}, Card{Four, Clubs}, Card{Three, Diamonds}, Card{Two, Spades}),
+			game: DeuceToSeven,
+			want: LowballStraight,
+		},
+		{
+			name: "2-7 flush counts",
+			hand: NewHand(Card{Eight, Hearts}, Card{Six, Hearts}, Card{Four, Hearts}, Card{Three, Hearts}, Card{Two, Hearts}),
+			game: DeuceToSeven,
+			want: LowballFlush,
+		},
+		{
+			name: "A-5 wheel is the best hand",
+			hand: NewHand(Card{Ace, Spades}, Card{Five, Hearts}, Card{Four, Clubs}, Card{Three, Diamonds}, Card{Two, Spades}),
+			game: AceToFive,
+			want: LowballNoPair,
+		},
+		{
+			name: "A-5 ignores flushes",
+			hand: NewHand(Card{Eight, Hearts}, Card{Six, Hearts}, Card{Four, Hearts}, Card{Three, Hearts}, Card{Two, Hearts}),
+			game: AceToFive,
+			want: LowballNoPair,
+		},
+		{
+			name: "Full house",
+			hand: NewHand(Card{Eight, Hearts}, Card{Eight, Spades}, Card{Eight, Clubs}, Card{Three, Hearts}, Card{Three, Spades}),
+			game: AceToFive,
+			want: LowballFullHouse,
+		},
+		{
+			name: "Best five of seven avoids the pair",
+			hand: NewHand(Card{King, Hearts}, Card{King, Spades}, Card{Seven, Clubs}, Card{Five, Hearts}, Card{Four, Spades}, Card{Three, Clubs}, Card{Two, Diamonds}),
+			game: DeuceToSeven,
+			want: LowballNoPair,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.hand.EvaluateLowball(tt.game)
+			if got.Type != tt.want {
+				t.Errorf("EvaluateLowball() = %v, want %v", got.Type, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateLowballTooFewCards(t *testing.T) {
+	hand := NewHand(Card{Two, Spades}, Card{Three, Spades})
+	if got := hand.EvaluateLowball(DeuceToSeven); got != nil {
+		t.Errorf("EvaluateLowball() = %v, want nil", got)
+	}
+}
+
+func TestCompareLowballHands(t *testing.T) {
+	tests := []struct {
+		name  string
+		hand1 Hand
+		hand2 Hand
+		game  LowballGame
+		want  Result
+	}{
+		{
+			name:  "2-7 seven low beats eight low",
+			hand1: NewHand(Card{Seven, Spades}, Card{Five, Hearts}, Card{Four, Clubs}, Card{Three, Diamonds}, Card{Two, Spades}),
+			hand2: NewHand(Card{Eight, Spades}, Card{Five, Clubs}, Card{Four, Hearts}, Card{Three, Clubs}, Card{Two, Hearts}),
+			game:  DeuceToSeven,
+			want:  Win,
+		},
+		{
+			name:  "2-7 ace is high",
+			hand1: NewHand(Card{Ace, Spades}, Card{Five, Hearts}, Card{Four, Clubs}, Card{Three, Diamonds}, Card{Two, Spades}),
+			hand2: NewHand(Card{King, Spades}, Card{Five, Clubs}, Card{Four, Hearts}, Card{Three, Clubs}, Card{Two, Hearts}),
+			game:  DeuceToSeven,
+			want:  Lose,
+		},
+		{
+			name:  "A-5 wheel beats six low",
+			hand1: NewHand(Card{Ace, Spades}, Card{Five, Hearts}, Card{Four, Clubs}, Card{Three, Diamonds}, Card{Two, Spades}),
+			hand2: NewHand(Card{Six, Spades}, Card{Four, Hearts}, Card{Three, Clubs}, Card{Two, Clubs}, Card{Ace, Hearts}),
+			game:  AceToFive,
+			want:  Win,
+		},
+		{
+			name:  "Second card decides",
+			hand1: NewHand(Card{Eight, Spades}, Card{Six, Hearts}, Card{Four, Clubs}, Card{Three, Diamonds}, Card{Two, Spades}),
+			hand2: NewHand(Card{Eight, Clubs}, Card{Five, Clubs}, Card{Four, Hearts}, Card{Three, Clubs}, Card{Two, Hearts}),
+			game:  DeuceToSeven,
+			want:  Lose,
+		},
+		{
+			name:  "Lower pair wins",
+			hand1: NewHand(Card{Two, Spades}, Card{Two, Hearts}, Card{King, Clubs}, Card{Queen, Diamonds}, Card{Jack, Spades}),
+			hand2: NewHand(Card{Three, Clubs}, Card{Three, Diamonds}, Card{Four, Hearts}, Card{Five, Clubs}, Card{Six, Hearts}),
+			game:  AceToFive,
+			want:  Win,
+		},
+		{
+			name:  "Same ranks tie",
+			hand1: NewHand(Card{Seven, Spades}, Card{Five, Hearts}, Card{Four, Clubs}, Card{Three, Diamonds}, Card{Two, Spades}),
+			hand2: NewHand(Card{Seven, Clubs}, Card{Five, Clubs}, Card{Four, Hearts}, Card{Three, Clubs}, Card{Two, Hearts}),
+			game:  DeuceToSeven,
+			want:  Tie,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, _, err := CompareLowballHands(tt.hand1, tt.hand2, tt.game)
+			if err != nil {
+				t.Fatalf("CompareLowballHands() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("CompareLowballHands() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareLowballHandsTooFewCards(t *testing.T) {
+	five := NewHand(Card{Seven, Spades}, Card{Five, Hearts}, Card{Four, Clubs}, Card{Three, Diamonds}, Card{Two, Spades})
+	two := NewHand(Card{Two, Hearts}, Card{Three, Hearts})
+
+	if _, _, _, err := CompareLowballHands(five, two, DeuceToSeven); !errors.Is(err, ErrTooFewLowballCards) {
+		t.Errorf("CompareLowballHands() error = %v, want %v", err, ErrTooFewLowballCards)
+	}
+	if _, _, _, err := CompareLowballHands(two, five, AceToFive); !errors.Is(err, ErrTooFewLowballCards) {
+		t.Errorf("CompareLowballHands() error = %v, want %v", err, ErrTooFewLowballCards)
+	}
+}
+
+func TestLowballDiscards(t *testing.T) {
+	hand := NewHand(Card{King, Spades}, Card{Four, Hearts}, Card{Four, Clubs}, Card{Three, Diamonds}, Card{Two, Spades})
+
+	discards := hand.LowballDiscards(2, DeuceToSeven)
+	if len(discards) != 2 {
+		t.Fatalf("expected 2 discards, got %d", len(discards))
+	}
+
+	ranks := map[Rank]bool{discards[0].Rank: true, discards[1].Rank: true}
+	if !ranks[Four] || !ranks[King] {
+		t.Errorf("expected a Four and the King to be discarded, got %v", discards)
+	}
+}
@@ -0,0 +1,86 @@
+package poker
+
+import (
+	"slices"
+)
+
+type ShowdownEntry struct {
+	Player      int
+	Place       int
+	Rank        HandRank
+	Description string
+}
+
+type ShowdownResult struct {
+	Ranking []ShowdownEntry
+}
+
+// Showdown ranks every hand against the board, best first. Players are
+// identified by their index in hands and tied hands share the same place.
+func Showdown(hands []Hand, communityCards []Card) ShowdownResult {
+	ranking := make([]ShowdownEntry, len(hands))
+
+	for i, hand := range hands {
+		rank := hand.EvaluateHandStrenght(communityCards)
+		ranking[i] = ShowdownEntry{
+			Player:      i,
+			Rank:        *rank,
+			Description: rank.Describe(),
+		}
+	}
+
+	slices.SortStableFunc(ranking, func(a, b ShowdownEntry) int {
+		return b.Rank.Compare(a.Rank)
+	})
+
+	for i := range ranking {
+		if i > 0 && ranking[i].Rank.Compare(ranking[i-1].Rank) == 0 {
+			ranking[i].Place = ranking[i-1].Place
+		} else {
+			ranking[i].Place = i + 1
+		}
+	}
+
+	return ShowdownResult{Ranking: ranking}
+}
+
+func (s ShowdownResult) Winners() []int {
+	var players []int
+	for _, entry := range s.Ranking {
+		players = append(players, entry.Player)
+	}
+	return s.WinnersAmong(players)
+}
+
+// WinnersAmong returns the players that win a pot contested only by the
+// eligible players, in seat order. More than one winner means a split pot.
+func (s ShowdownResult) WinnersAmong(eligible []int) []int {
+	var winners []int
+	var best *HandRank
+
+	for _, entry := range s.Ranking {
+		if !slices.Contains(eligible, entry.Player) {
+			continue
+		}
+		if best == nil {
+			best = &entry.Rank
+		}
+		if entry.Rank.Compare(*best) < 0 {
+			break
+		}
+		winners = append(winners, entry.Player)
+	}
+
+	slices.Sort(winners)
+
+	return winners
+}
+
+func (s ShowdownResult) Entry(player int) (ShowdownEntry, bool) {
+	for _, entry := range s.Ranking {
+		if entry.Player == player {
+			return entry, true
+		}
+	}
+	return ShowdownEntry{}, false
+}
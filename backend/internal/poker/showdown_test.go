@@ -0,0 +1,51 @@
+package poker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestShowdown(t *testing.T) {
+	communityCards := []Card{{King, Clubs}, {King, Diamonds}, {Seven, Hearts}, {Four, Spades}, {Two, Clubs}}
+	hands := []Hand{
+		NewHand(Card{Ace, Spades}, Card{Three, Hearts}),
+		NewHand(Card{Seven, Spades}, Card{Eight, Hearts}),
+		NewHand(Card{Ace, Hearts}, Card{Three, Clubs}),
+		NewHand(Card{Queen, Spades}, Card{Jack, Hearts}),
+	}
+
+	result := Showdown(hands, communityCards)
+
+	if got := result.Winners(); !reflect.DeepEqual(got, []int{1}) {
+		t.Errorf("Winners() = %v, want [1]", got)
+	}
+
+	if got := result.WinnersAmong([]int{0, 2, 3}); !reflect.DeepEqual(got, []int{0, 2}) {
+		t.Errorf("WinnersAmong() = %v, want [0 2]", got)
+	}
+
+	wantPlaces := map[int]int{1: 1, 0: 2, 2: 2, 3: 4}
+	for _, entry := range result.Ranking {
+		if entry.Place != wantPlaces[entry.Player] {
+			t.Errorf("player %d place = %d, want %d", entry.Player, entry.Place, wantPlaces[entry.Player])
+		}
+	}
+
+	entry, ok := result.Entry(1)
+	if !ok || entry.Description != "Two Pair, Kings and Sevens with an Eight kicker" {
+		t.Errorf("Entry(1) = %v, %v", entry, ok)
+	}
+}
+
+func TestShowdownBoardPlays(t *testing.T) {
+	communityCards := []Card{{Ace, Clubs}, {King, Clubs}, {Queen, Diamonds}, {Jack, Hearts}, {Ten, Spades}}
+	hands := []Hand{
+		NewHand(Card{Two, Spades}, Card{Three, Hearts}),
+		NewHand(Card{Two, Diamonds}, Card{Four, Hearts}),
+		NewHand(Card{Five, Diamonds}, Card{Six, Hearts}),
+	}
+
+	if got := Showdown(hands, communityCards).Winners(); !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Errorf("Winners() = %v, want [0 1 2]", got)
+	}
+}
@@ -0,0 +1,94 @@
+package pot
+
+import (
+	"slices"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+)
+
+type Pot struct {
+	Amount   int
+	Eligible []int
+}
+
+// BuildPots splits per-seat contributions into a main pot and side pots.
+// Folded seats add chips to the pots they paid into but cannot win them.
+func BuildPots(contributions []int, folded []bool) []Pot {
+	var levels []int
+	for seat, amount := range contributions {
+		if amount > 0 && !isFolded(folded, seat) && !slices.Contains(levels, amount) {
+			levels = append(levels, amount)
+		}
+	}
+	slices.Sort(levels)
+
+	var pots []Pot
+	previous := 0
+
+	for _, level := range levels {
+		pot := Pot{}
+		for seat, amount := range contributions {
+			pot.Amount += max(0, min(amount, level)-previous)
+			if amount >= level && !isFolded(folded, seat) {
+				pot.Eligible = append(pot.Eligible, seat)
+			}
+		}
+		pots = append(pots, pot)
+		previous = level
+	}
+
+	// Chips put in by folded seats above the highest live contribution go to
+	// the last pot rather than being lost.
+	for _, amount := range contributions {
+		if amount > previous && len(pots) > 0 {
+			pots[len(pots)-1].Amount += amount - previous
+		}
+	}
+
+	return pots
+}
+
+func isFolded(folded []bool, seat int) bool {
+	return seat < len(folded) && folded[seat]
+}
+
+// Distribute pays out every pot to its winners. Split pots are shared evenly
+// and odd chips go to the winners closest to the left of the button.
+func Distribute(pots []Pot, showdown poker.ShowdownResult, button int, numSeats int) []int {
+	payouts := make([]int, numSeats)
+
+	for _, pot := range pots {
+		winners := showdown.WinnersAmong(pot.Eligible)
+		if len(winners) == 0 {
+			continue
+		}
+
+		share := pot.Amount / len(winners)
+		for _, seat := range winners {
+			payouts[seat] += share
+		}
+
+		oddChips := pot.Amount % len(winners)
+		for _, seat := range seatsFromButton(winners, button, numSeats)[:oddChips] {
+			payouts[seat]++
+		}
+	}
+
+	return payouts
+}
+
+func seatsFromButton(seats []int, button int, numSeats int) []int {
+	ordered := slices.Clone(seats)
+	slices.SortFunc(ordered, func(a, b int) int {
+		return distanceFromButton(a, button, numSeats) - distanceFromButton(b, button, numSeats)
+	})
+	return ordered
+}
+
+func distanceFromButton(seat int, button int, numSeats int) int {
+	distance := (seat - button + numSeats) % numSeats
+	if distance == 0 {
+		return numSeats
+	}
+	return distance
+}
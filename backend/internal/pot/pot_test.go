@@ -0,0 +1,75 @@
+package pot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+)
+
+func TestBuildPots(t *testing.T) {
+	tests := []struct {
+		name          string
+		contributions []int
+		folded        []bool
+		want          []Pot
+	}{
+		{
+			name:          "Single pot",
+			contributions: []int{100, 100, 100},
+			want:          []Pot{{Amount: 300, Eligible: []int{0, 1, 2}}},
+		},
+		{
+			name:          "Short stack all-in creates side pot",
+			contributions: []int{50, 200, 200},
+			want: []Pot{
+				{Amount: 150, Eligible: []int{0, 1, 2}},
+				{Amount: 300, Eligible: []int{1, 2}},
+			},
+		},
+		{
+			name:          "Three different all-ins",
+			contributions: []int{300, 100, 200, 300},
+			want: []Pot{
+				{Amount: 400, Eligible: []int{0, 1, 2, 3}},
+				{Amount: 300, Eligible: []int{0, 2, 3}},
+				{Amount: 200, Eligible: []int{0, 3}},
+			},
+		},
+		{
+			name:          "Folded player's chips stay in the pot",
+			contributions: []int{40, 100, 100},
+			folded:        []bool{true, false, false},
+			want:          []Pot{{Amount: 240, Eligible: []int{1, 2}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BuildPots(tt.contributions, tt.folded); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BuildPots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDistribute(t *testing.T) {
+	communityCards := []poker.Card{{Rank: poker.King, Suit: poker.Clubs}, {Rank: poker.King, Suit: poker.Diamonds}, {Rank: poker.Seven, Suit: poker.Hearts}, {Rank: poker.Four, Suit: poker.Spades}, {Rank: poker.Two, Suit: poker.Clubs}}
+	hands := []poker.Hand{
+		poker.NewHand(poker.Card{Rank: poker.Seven, Suit: poker.Spades}, poker.Card{Rank: poker.Eight, Suit: poker.Hearts}),
+		poker.NewHand(poker.Card{Rank: poker.Ace, Suit: poker.Spades}, poker.Card{Rank: poker.Three, Suit: poker.Hearts}),
+		poker.NewHand(poker.Card{Rank: poker.Ace, Suit: poker.Hearts}, poker.Card{Rank: poker.Three, Suit: poker.Clubs}),
+		poker.NewHand(poker.Card{Rank: poker.Queen, Suit: poker.Spades}, poker.Card{Rank: poker.Jack, Suit: poker.Hearts}),
+	}
+	showdown := poker.Showdown(hands, communityCards)
+
+	pots := BuildPots([]int{50, 200, 200, 101}, nil)
+	got := Distribute(pots, showdown, 1, 4)
+
+	// Seat 0 wins the main pot, seats 1 and 2 split the rest. The odd chip
+	// goes to seat 2, the first winner left of the button.
+	want := []int{200, 175, 176, 0}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Distribute() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,180 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/metrics"
+)
+
+var rejected = metrics.Default.NewCounterVec("ratelimit_rejected_total",
+	"Requests turned away, by the limit they hit: rate or workers.", "limit")
+
+// Limiter is a token bucket per client. Each client may make burst requests
+// at once, and gets rate more every second.
+type Limiter struct {
+	rate  float64
+	burst float64
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastPrune time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter returns a limiter, or nil, which allows everything, when rate
+// is not positive.
+func NewLimiter(rate float64, burst int) *Limiter {
+	if rate <= 0 {
+		return nil
+	}
+	return &Limiter{
+		rate:    rate,
+		burst:   float64(max(burst, 1)),
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// Allow takes a token from the client's bucket. When the bucket is empty it
+// returns false and how long until the next token.
+func (l *Limiter) Allow(key string, now time.Time) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.prune(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		rejected.With("rate").Inc()
+		wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+		return false, wait
+	}
+
+	b.tokens--
+	return true, 0
+}
+
+// prune forgets clients whose bucket has filled up again, at most once a
+// minute, so the map does not grow with every address ever seen.
+func (l *Limiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < time.Minute {
+		return
+	}
+	l.lastPrune = now
+
+	full := time.Duration(l.burst / l.rate * float64(time.Second))
+	for key, b := range l.buckets {
+		if now.Sub(b.last) > full {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// WorkerPool caps the simulation workers running at once across every
+// request. A nil pool has no cap.
+type WorkerPool struct {
+	capacity int
+
+	mu      sync.Mutex
+	used    int
+	changed chan struct{}
+}
+
+func NewWorkerPool(capacity int) *WorkerPool {
+	return &WorkerPool{capacity: capacity, changed: make(chan struct{})}
+}
+
+// TryAcquire takes n workers if they are free right now. Asking for more
+// than the whole pool takes the whole pool. It returns how many workers
+// were taken, which must be given back with Release.
+func (p *WorkerPool) TryAcquire(n int) (int, bool) {
+	if p == nil {
+		return n, true
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	n = min(n, p.capacity)
+	if p.used+n > p.capacity {
+		rejected.With("workers").Inc()
+		return 0, false
+	}
+	p.used += n
+	return n, true
+}
+
+// Acquire is TryAcquire that waits for the workers to be free, until ctx is
+// done.
+func (p *WorkerPool) Acquire(ctx context.Context, n int) (int, error) {
+	if p == nil {
+		return n, nil
+	}
+
+	for {
+		p.mu.Lock()
+		n = min(n, p.capacity)
+		if p.used+n <= p.capacity {
+			p.used += n
+			p.mu.Unlock()
+			return n, nil
+		}
+		changed := p.changed
+		p.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
+
+func (p *WorkerPool) Release(n int) {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.used -= n
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
+
+// InUse is how many workers are taken.
+func (p *WorkerPool) InUse() int {
+	if p == nil {
+		return 0
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.used
+}
+
+func (p *WorkerPool) Capacity() int {
+	if p == nil {
+		return 0
+	}
+	return p.capacity
+}
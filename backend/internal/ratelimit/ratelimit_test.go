@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiter(t *testing.T) {
+	limiter := NewLimiter(2, 3)
+	start := time.Now()
+
+	tests := []struct {
+		name     string
+		key      string
+		at       time.Duration
+		want     bool
+		wantWait time.Duration
+	}{
+		{"Burst 1", "a", 0, true, 0},
+		{"Burst 2", "a", 0, true, 0},
+		{"Burst 3", "a", 0, true, 0},
+		{"Empty", "a", 0, false, 500 * time.Millisecond},
+		{"Other client", "b", 0, true, 0},
+		{"Half a token", "a", 250 * time.Millisecond, false, 250 * time.Millisecond},
+		{"Refilled", "a", 500 * time.Millisecond, true, 0},
+	}
+
+	for _, tt := range tests {
+		got, wait := limiter.Allow(tt.key, start.Add(tt.at))
+		if got != tt.want || wait != tt.wantWait {
+			t.Errorf("%s: Allow() = %v, %v, want %v, %v", tt.name, got, wait, tt.want, tt.wantWait)
+		}
+	}
+}
+
+func TestLimiterPrune(t *testing.T) {
+	limiter := NewLimiter(1, 2)
+	start := time.Now()
+
+	limiter.Allow("a", start)
+	limiter.Allow("b", start.Add(2*time.Minute))
+
+	if _, ok := limiter.buckets["a"]; ok {
+		t.Errorf("idle client was not pruned")
+	}
+	if _, ok := limiter.buckets["b"]; !ok {
+		t.Errorf("active client was pruned")
+	}
+}
+
+func TestNilLimiter(t *testing.T) {
+	if ok, _ := NewLimiter(0, 10).Allow("a", time.Now()); !ok {
+		t.Errorf("a limiter without a rate should allow everything")
+	}
+}
+
+func TestWorkerPool(t *testing.T) {
+	pool := NewWorkerPool(4)
+
+	if n, ok := pool.TryAcquire(3); !ok || n != 3 {
+		t.Fatalf("TryAcquire(3) = %d, %v", n, ok)
+	}
+	if _, ok := pool.TryAcquire(2); ok {
+		t.Errorf("TryAcquire(2) succeeded with 1 worker free")
+	}
+
+	done := make(chan int)
+	go func() {
+		n, _ := pool.Acquire(context.Background(), 2)
+		done <- n
+	}()
+
+	select {
+	case <-done:
+		t.Fatalf("Acquire(2) did not wait for free workers")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	pool.Release(3)
+	if n := <-done; n != 2 || pool.InUse() != 2 {
+		t.Errorf("Acquire(2) = %d with %d in use", n, pool.InUse())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := pool.Acquire(ctx, 4); err == nil {
+		t.Errorf("Acquire() with a canceled context succeeded")
+	}
+
+	pool.Release(2)
+	if n, ok := pool.TryAcquire(10); !ok || n != 4 {
+		t.Errorf("TryAcquire(10) = %d, %v, want the whole pool", n, ok)
+	}
+}
@@ -0,0 +1,242 @@
+package replay
+
+import (
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/game"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/handhistory"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/metrics"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+	simulator "github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/simulation"
+)
+
+var (
+	cacheHits = metrics.Default.NewCounter("replay_equity_cache_hits_total",
+		"Replay steps whose equity was reused from an earlier step.")
+	cacheMisses = metrics.Default.NewCounter("replay_equity_cache_misses_total",
+		"Replay steps whose equity had to be computed.")
+)
+
+func init() {
+	metrics.Default.NewGaugeFunc("replay_equity_cache_hit_ratio",
+		"Share of replay steps whose equity came from the cache.", func() float64 {
+			hits, misses := cacheHits.Value(), cacheMisses.Value()
+			if hits+misses == 0 {
+				return 0
+			}
+			return hits / (hits + misses)
+		})
+}
+
+type Options struct {
+	NumIterations int
+	NumConcurrent int
+}
+
+// Step is the table after one event of the hand. Action is nil for steps
+// that deal the board or pay out the pot. Every hole card known from the
+// history is visible from the start, as on a televised table.
+type Step struct {
+	Street      game.Street             `json:"street"`
+	StreetName  string                  `json:"streetName"`
+	Action      *handhistory.Action     `json:"action,omitempty"`
+	Description string                  `json:"description"`
+	Board       []poker.Card            `json:"board"`
+	Pot         float64                 `json:"pot"`
+	Stacks      map[string]float64      `json:"stacks"`
+	Folded      []string                `json:"folded"`
+	HoleCards   map[string][]poker.Card `json:"holeCards"`
+	Equity      map[string]float64      `json:"equity,omitempty"`
+}
+
+type replayer struct {
+	hand    handhistory.Hand
+	options Options
+	street  game.Street
+	pot     float64
+	stacks  map[string]float64
+	folded  []string
+	equity  map[string]map[string]float64
+	steps   []Step
+
+	cacheHits   int
+	simulations int
+}
+
+// Validate reports cards in the hand that do not exist or are dealt twice.
+// Build finds them too, but only once it reaches them, after simulating
+// the streets before.
+func Validate(hand handhistory.Hand) error {
+	groups := [][]poker.Card{hand.Board}
+	for _, cards := range hand.HoleCards {
+		groups = append(groups, cards)
+	}
+	return simulator.CheckCards(groups...)
+}
+
+// Build replays the hand action by action. Equity is computed for every
+// shown player still in the hand, against random cards for anyone whose
+// cards were never shown, and only changes when the board or the set of
+// remaining players does.
+func Build(hand handhistory.Hand, options Options) ([]Step, error) {
+	start := time.Now()
+	r := &replayer{
+		hand:    hand,
+		options: options,
+		stacks:  make(map[string]float64),
+		equity:  make(map[string]map[string]float64),
+	}
+
+	for _, seat := range hand.Seats {
+		r.stacks[seat.Player] = seat.Stack
+	}
+
+	if err := r.record(nil, "Hand #"+hand.ID); err != nil {
+		return nil, err
+	}
+
+	for _, action := range hand.Actions {
+		if action.Street != r.street && action.Street != game.Showdown {
+			r.street = action.Street
+			if err := r.record(nil, r.street.String()+" dealt"); err != nil {
+				return nil, err
+			}
+		}
+
+		switch action.Type {
+		case handhistory.UncalledBet:
+			r.stacks[action.Player] += action.Amount
+			r.pot -= action.Amount
+		case handhistory.Fold:
+			r.folded = append(r.folded, action.Player)
+		default:
+			r.stacks[action.Player] -= action.Amount
+			r.pot += action.Amount
+		}
+
+		if err := r.record(&action, action.Player+": "+action.Type.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	for len(hand.Board) > len(hand.BoardAt(r.street)) && r.street < game.River {
+		r.street++
+		if err := r.record(nil, r.street.String()+" dealt"); err != nil {
+			return nil, err
+		}
+	}
+
+	r.street = game.Showdown
+	for player, amount := range hand.Winnings {
+		r.stacks[player] += amount
+	}
+	r.pot = 0
+
+	if err := r.record(nil, "Pot awarded"); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("replay built",
+		"hand", hand.ID,
+		"steps", len(r.steps),
+		"equityCacheHits", r.cacheHits,
+		"simulations", r.simulations,
+		"duration", time.Since(start),
+	)
+
+	return r.steps, nil
+}
+
+func (r *replayer) record(action *handhistory.Action, description string) error {
+	step := Step{
+		Street:      r.street,
+		StreetName:  r.street.String(),
+		Action:      action,
+		Description: description,
+		Board:       r.hand.BoardAt(r.street),
+		Pot:         r.pot,
+		Stacks:      make(map[string]float64, len(r.stacks)),
+		Folded:      slices.Clone(r.folded),
+		HoleCards:   make(map[string][]poker.Card),
+	}
+
+	for player, stack := range r.stacks {
+		step.Stacks[player] = stack
+	}
+
+	for player, cards := range r.hand.HoleCards {
+		step.HoleCards[player] = cards
+	}
+
+	equity, err := r.equityFor(step.Board)
+	if err != nil {
+		return err
+	}
+	step.Equity = equity
+
+	r.steps = append(r.steps, step)
+	return nil
+}
+
+func (r *replayer) equityFor(board []poker.Card) (map[string]float64, error) {
+	var live []string
+	for _, seat := range r.hand.Seats {
+		if seat.SittingOut || slices.Contains(r.folded, seat.Player) {
+			continue
+		}
+		live = append(live, seat.Player)
+	}
+
+	key := r.street.String()
+	for _, player := range live {
+		key += "|" + player
+	}
+	if equity, ok := r.equity[key]; ok {
+		r.cacheHits++
+		cacheHits.Inc()
+		return equity, nil
+	}
+	cacheMisses.Inc()
+
+	equity := make(map[string]float64)
+
+	known := 0
+	iterations := max(r.options.NumIterations, 1)
+	config := simulator.AllInConfig{
+		CommunityCards: board,
+		NumIterations:  iterations,
+		NumConcurrent:  min(max(r.options.NumConcurrent, 1), iterations),
+	}
+	for _, player := range live {
+		cards := r.hand.HoleCards[player]
+		if len(cards) == 2 {
+			known++
+		} else {
+			cards = nil
+		}
+		config.Hands = append(config.Hands, poker.NewHand(cards...))
+		config.Contributions = append(config.Contributions, 1)
+	}
+
+	switch {
+	case known == 0:
+	case len(live) == 1:
+		equity[live[0]] = 1
+	default:
+		r.simulations++
+		result, err := simulator.NewAllInSimulator(config).RunSimulation()
+		if err != nil {
+			return nil, err
+		}
+		for i, player := range live {
+			if len(r.hand.HoleCards[player]) == 2 {
+				equity[player] = result.Equity[i]
+			}
+		}
+	}
+
+	r.equity[key] = equity
+	return equity, nil
+}
@@ -0,0 +1,85 @@
+package replay
+
+import (
+	"math"
+	"os"
+	"testing"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/game"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/handhistory"
+)
+
+func TestBuild(t *testing.T) {
+	file, err := os.Open("../handhistory/testdata/cash.txt")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer file.Close()
+
+	hands, err := handhistory.ParsePokerStars(file)
+	if err != nil {
+		t.Fatalf("ParsePokerStars() error = %v", err)
+	}
+
+	steps, err := Build(hands[0], Options{NumIterations: 2000, NumConcurrent: 2})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	// The start, every action, the flop, turn and river and the payout.
+	if len(steps) != len(hands[0].Actions)+5 {
+		t.Fatalf("expected %d steps, got %d", len(hands[0].Actions)+5, len(steps))
+	}
+
+	first := steps[0]
+	if first.Pot != 0 || first.Stacks["hero"] != 2 || len(first.Board) != 0 {
+		t.Errorf("unexpected first step: %+v", first)
+	}
+
+	var turn Step
+	for _, step := range steps {
+		if step.Action == nil && step.Street == game.Turn {
+			turn = step
+		}
+	}
+	if len(turn.Board) != 4 || math.Abs(turn.Pot-2.66) > 1e-9 || len(turn.Folded) != 2 {
+		t.Errorf("unexpected turn step: %+v", turn)
+	}
+	if math.Abs(turn.Equity["hero"]-2.0/44) > 0.03 {
+		t.Errorf("hero turn equity = %v, want about %v", turn.Equity["hero"], 2.0/44)
+	}
+
+	last := steps[len(steps)-1]
+	if last.Street != game.Showdown || last.Pot != 0 || math.Abs(last.Stacks["fish: 77"]-3.34) > 1e-9 {
+		t.Errorf("unexpected final step: %+v", last)
+	}
+	if last.Equity["fish: 77"] != 1 {
+		t.Errorf("winner equity on the river = %v, want 1", last.Equity["fish: 77"])
+	}
+}
+
+func TestBuildWithFewerIterationsThanWorkers(t *testing.T) {
+	file, err := os.Open("../handhistory/testdata/cash.txt")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer file.Close()
+
+	hands, err := handhistory.ParsePokerStars(file)
+	if err != nil {
+		t.Fatalf("ParsePokerStars() error = %v", err)
+	}
+
+	steps, err := Build(hands[0], Options{NumIterations: 3, NumConcurrent: 8})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	for _, step := range steps {
+		for player, equity := range step.Equity {
+			if math.IsNaN(equity) {
+				t.Fatalf("equity of %s is NaN at %+v", player, step)
+			}
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/pot"
+)
+
+type AllInConfig struct {
+	Hands          []poker.Hand
+	Contributions  []int
+	Folded         []bool
+	Button         int
+	CommunityCards []poker.Card
+	DeadCards      []poker.Card
+	NumIterations  int
+	NumConcurrent  int
+	Exact          bool
+}
+
+type AllInResult struct {
+	ExpectedChips []float64
+	Equity        []float64
+	Iterations    int
+}
+
+type AllInSimulator struct {
+	config AllInConfig
+}
+
+func NewAllInSimulator(config AllInConfig) *AllInSimulator {
+	return &AllInSimulator{config: config}
+}
+
+func (s *AllInSimulator) validate() error {
+	if s.config.Exact {
+		if s.config.NumConcurrent <= 0 {
+			return fmt.Errorf("%w: workers must be positive", ErrIterations)
+		}
+	} else if err := checkIterations(s.config.NumIterations, s.config.NumConcurrent); err != nil {
+		return err
+	}
+	if len(s.config.Hands) < 2 {
+		return fmt.Errorf("%w: at least two hands are required", ErrCardCount)
+	}
+	if len(s.config.Contributions) != len(s.config.Hands) {
+		return fmt.Errorf("%w: %d contributions given for %d hands", ErrCardCount, len(s.config.Contributions), len(s.config.Hands))
+	}
+	if len(s.config.CommunityCards) > 5 {
+		return fmt.Errorf("%w: at most 5 community cards are allowed", ErrCardCount)
+	}
+
+	needed := 5 - len(s.config.CommunityCards)
+	for seat, hand := range s.config.Hands {
+		if len(hand.Cards) > 2 {
+			return fmt.Errorf("%w: hands must have at most 2 cards", ErrCardCount)
+		}
+		folded := seat < len(s.config.Folded) && s.config.Folded[seat]
+		if s.config.Exact && !folded && len(hand.Cards) != 2 {
+			return fmt.Errorf("%w: exact enumeration needs every hand to be known", ErrCardCount)
+		}
+		needed += 2 - len(hand.Cards)
+	}
+
+	known, err := checkCards(s.knownCards()...)
+	if err != nil {
+		return err
+	}
+	if 52-known < needed {
+		return fmt.Errorf("%w: %d cards needed, %d left in the deck", ErrNotEnoughCards, needed, 52-known)
+	}
+
+	return nil
+}
+
+func (s *AllInSimulator) RunSimulation() (*AllInResult, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+
+	var totals allInTotals
+	if s.config.Exact {
+		totals = s.enumerate()
+	} else {
+		totals = s.simulate()
+	}
+
+	totalPot := 0
+	for _, amount := range s.config.Contributions {
+		totalPot += amount
+	}
+
+	result := &AllInResult{
+		ExpectedChips: make([]float64, len(totals.payouts)),
+		Equity:        make([]float64, len(totals.payouts)),
+		Iterations:    totals.iterations,
+	}
+
+	for seat, total := range totals.payouts {
+		result.ExpectedChips[seat] = float64(total) / float64(totals.iterations)
+		if totalPot > 0 {
+			result.Equity[seat] = result.ExpectedChips[seat] / float64(totalPot)
+		}
+	}
+
+	observe("allin", totals.iterations, time.Since(start))
+	slog.Debug("all-in simulation finished",
+		"hands", len(s.config.Hands),
+		"iterations", totals.iterations,
+		"exact", s.config.Exact,
+		"workers", s.config.NumConcurrent,
+		"duration", time.Since(start),
+	)
+
+	return result, nil
+}
+
+type allInTotals struct {
+	payouts    []int
+	iterations int
+}
+
+func (t *allInTotals) add(payouts []int) {
+	for seat, amount := range payouts {
+		t.payouts[seat] += amount
+	}
+	t.iterations++
+}
+
+func (t *allInTotals) merge(other allInTotals) {
+	for seat, amount := range other.payouts {
+		t.payouts[seat] += amount
+	}
+	t.iterations += other.iterations
+}
+
+// simulate deals random run-outs, splitting the iterations between workers.
+func (s *AllInSimulator) simulate() allInTotals {
+	var mu sync.Mutex
+	pots := pot.BuildPots(s.config.Contributions, s.config.Folded)
+	totals := allInTotals{payouts: make([]int, len(s.config.Hands))}
+
+	runWorkers(context.Background(), s.config.NumIterations, s.config.NumConcurrent, func(n int) {
+		local := allInTotals{payouts: make([]int, len(s.config.Hands))}
+		for i := 0; i < n; i++ {
+			local.add(s.runSingleSimulation(pots))
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		totals.merge(local)
+	})
+
+	return totals
+}
+
+func (s *AllInSimulator) runSingleSimulation(pots []pot.Pot) []int {
+	deck := removeCards(poker.NewDeck(), s.knownCards()...)
+	deck.Shuffle()
+
+	hands := make([]poker.Hand, len(s.config.Hands))
+	for i, hand := range s.config.Hands {
+		cards := append([]poker.Card{}, hand.Cards...)
+		hands[i] = poker.NewHand(append(cards, deck.Draw(2-len(cards))...)...)
+	}
+
+	communityCards := append([]poker.Card{}, s.config.CommunityCards...)
+	communityCards = append(communityCards, deck.Draw(5-len(communityCards))...)
+
+	showdown := poker.Showdown(hands, communityCards)
+
+	return pot.Distribute(pots, showdown, s.config.Button, len(hands))
+}
+
+// enumerate deals every run-out of the board once, splitting the boards
+// between workers.
+func (s *AllInSimulator) enumerate() allInTotals {
+	results := make(chan allInTotals, s.config.NumConcurrent)
+
+	var wg sync.WaitGroup
+	for i := 0; i < s.config.NumConcurrent; i++ {
+		wg.Add(1)
+		go s.enumerationWorker(i, results, &wg)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	totals := allInTotals{payouts: make([]int, len(s.config.Hands))}
+	for worker := range results {
+		totals.merge(worker)
+	}
+	return totals
+}
+
+// enumerationWorker deals every possible run-out of the board exactly once
+// across all workers; worker i takes every NumConcurrent-th board.
+func (s *AllInSimulator) enumerationWorker(worker int, results chan<- allInTotals, wg *sync.WaitGroup) {
+	defer wg.Done()
+	activeWorkers.Inc()
+	defer activeWorkers.Dec()
+
+	pots := pot.BuildPots(s.config.Contributions, s.config.Folded)
+	totals := allInTotals{payouts: make([]int, len(s.config.Hands))}
+
+	deck := removeCards(poker.NewDeck(), s.knownCards()...)
+	missing := 5 - len(s.config.CommunityCards)
+
+	communityCards := make([]poker.Card, 5)
+	copy(communityCards, s.config.CommunityCards)
+
+	board := 0
+	var deal func(start, depth int)
+	deal = func(start, depth int) {
+		if depth == missing {
+			if board%s.config.NumConcurrent == worker {
+				showdown := poker.Showdown(s.config.Hands, communityCards)
+				totals.add(pot.Distribute(pots, showdown, s.config.Button, len(s.config.Hands)))
+			}
+			board++
+			return
+		}
+		for i := start; i <= len(deck.Cards)-(missing-depth); i++ {
+			communityCards[len(s.config.CommunityCards)+depth] = deck.Cards[i]
+			deal(i+1, depth+1)
+		}
+	}
+	deal(0, 0)
+
+	results <- totals
+}
+
+// knownCards returns every card that cannot be dealt: the hands, the board
+// and the dead cards.
+func (s *AllInSimulator) knownCards() [][]poker.Card {
+	groups := [][]poker.Card{s.config.CommunityCards, s.config.DeadCards}
+	for _, hand := range s.config.Hands {
+		groups = append(groups, hand.Cards)
+	}
+	return groups
+}
@@ -8,6 +8,51 @@ type Config struct {
 	PlayerHand     poker.Hand
 	OpponentHand   poker.Hand
 	CommunityCards []poker.Card
+	DeadCards      []poker.Card
 	NumIterations  int
 	NumConcurrent  int
 }
+
+// Request is a simulation as the HTTP and gRPC APIs take it, before their
+// defaults and limits are applied.
+type Request struct {
+	PlayerCards    []poker.Card
+	OpponentCards  []poker.Card
+	CommunityCards []poker.Card
+	DeadCards      []poker.Card
+	NumIterations  int
+	NumConcurrent  int
+}
+
+// Config turns the request into a Config with its iterations and workers
+// clamped to the given limits, and checks that it can be simulated.
+func (r Request) Config(maxIterations int, maxConcurrent int) (Config, error) {
+	if r.NumIterations <= 0 {
+		r.NumIterations = min(10_000, maxIterations)
+	} else if r.NumIterations > maxIterations {
+		r.NumIterations = maxIterations
+	}
+
+	if r.NumConcurrent <= 0 {
+		r.NumConcurrent = min(8, maxConcurrent)
+	} else if r.NumConcurrent > maxConcurrent {
+		r.NumConcurrent = maxConcurrent
+	}
+
+	// A worker without an iteration to run would be wasted.
+	r.NumConcurrent = min(r.NumConcurrent, r.NumIterations)
+
+	config := Config{
+		PlayerHand:     poker.NewHand(r.PlayerCards...),
+		OpponentHand:   poker.NewHand(r.OpponentCards...),
+		CommunityCards: r.CommunityCards,
+		DeadCards:      r.DeadCards,
+		NumIterations:  r.NumIterations,
+		NumConcurrent:  r.NumConcurrent,
+	}
+
+	if err := NewSimulator(config).Validate(); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
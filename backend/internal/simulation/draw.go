@@ -0,0 +1,113 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+)
+
+const (
+	SingleDraw = 1
+	TripleDraw = 3
+)
+
+type DrawConfig struct {
+	Game             poker.LowballGame
+	PlayerHand       poker.Hand
+	OpponentHand     poker.Hand
+	PlayerDiscards   int
+	OpponentDiscards int
+	NumDraws         int
+	NumIterations    int
+	NumConcurrent    int
+}
+
+type DrawSimulator struct {
+	config DrawConfig
+}
+
+func NewDrawSimulator(config DrawConfig) *DrawSimulator {
+	return &DrawSimulator{config: config}
+}
+
+func (s *DrawSimulator) validate() error {
+	if len(s.config.PlayerHand.Cards) != 5 {
+		return errors.New("player hand must have 5 cards")
+	}
+	if len(s.config.OpponentHand.Cards) > 5 {
+		return errors.New("opponent hand must have at most 5 cards")
+	}
+	if s.config.NumDraws < 0 || s.config.NumDraws > TripleDraw {
+		return errors.New("number of draws must be between 0 and 3")
+	}
+	if s.config.PlayerDiscards < 0 || s.config.PlayerDiscards > 5 ||
+		s.config.OpponentDiscards < 0 || s.config.OpponentDiscards > 5 {
+		return errors.New("discards must be between 0 and 5")
+	}
+	if err := checkIterations(s.config.NumIterations, s.config.NumConcurrent); err != nil {
+		return err
+	}
+
+	known, err := checkCards(s.config.PlayerHand.Cards, s.config.OpponentHand.Cards)
+	if err != nil {
+		return err
+	}
+
+	// Discards are not shuffled back in, so every draw comes from the stub.
+	needed := (5 - len(s.config.OpponentHand.Cards)) +
+		s.config.NumDraws*(s.config.PlayerDiscards+s.config.OpponentDiscards)
+	if 52-known < needed {
+		return ErrNotEnoughCards
+	}
+
+	return nil
+}
+
+func (s *DrawSimulator) RunSimulation() (*Result, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	result := runTally(context.Background(), s.config.NumIterations, s.config.NumConcurrent, s.runSingleSimulation, nil)
+	observe("draw", result.Iterations, time.Since(start))
+
+	return result, nil
+}
+
+func (s *DrawSimulator) runSingleSimulation() poker.Result {
+	deck := removeCards(poker.NewDeck(), s.config.PlayerHand.Cards, s.config.OpponentHand.Cards)
+	deck.Shuffle()
+
+	playerHand := poker.NewHand(append([]poker.Card{}, s.config.PlayerHand.Cards...)...)
+	opponentHand := poker.NewHand(append([]poker.Card{}, s.config.OpponentHand.Cards...)...)
+	opponentHand.Cards = append(opponentHand.Cards, deck.Draw(5-len(opponentHand.Cards))...)
+
+	for i := 0; i < s.config.NumDraws; i++ {
+		playerHand = drawReplacements(playerHand, s.config.PlayerDiscards, s.config.Game, &deck)
+		opponentHand = drawReplacements(opponentHand, s.config.OpponentDiscards, s.config.Game, &deck)
+	}
+
+	// validate makes sure both hands end up with 5 cards.
+	result, _, _, _ := poker.CompareLowballHands(playerHand, opponentHand, s.config.Game)
+
+	return result
+}
+
+func drawReplacements(hand poker.Hand, discards int, game poker.LowballGame, deck *poker.Deck) poker.Hand {
+	thrown := make(map[poker.Card]bool)
+	for _, card := range hand.LowballDiscards(discards, game) {
+		thrown[card] = true
+	}
+
+	var kept []poker.Card
+	for _, card := range hand.Cards {
+		if !thrown[card] {
+			kept = append(kept, card)
+		}
+	}
+
+	return poker.NewHand(append(kept, deck.Draw(len(thrown))...)...)
+}
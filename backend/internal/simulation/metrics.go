@@ -0,0 +1,37 @@
+package simulator
+
+import (
+	"time"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/metrics"
+)
+
+var (
+	simulationsTotal = metrics.Default.NewCounterVec("simulations_total",
+		"Simulations run, by kind.", "kind")
+	iterationsTotal = metrics.Default.NewCounterVec("simulation_iterations_total",
+		"Iterations simulated, by kind.", "kind")
+	simulationDuration = metrics.Default.NewHistogramVec("simulation_duration_seconds",
+		"Time taken by one simulation, by kind.", metrics.DefBuckets, "kind")
+	iterationsPerSecond = metrics.Default.NewGaugeVec("simulation_iterations_per_second",
+		"Throughput of the last finished simulation, by kind.", "kind")
+	activeWorkers = metrics.Default.NewGauge("simulation_active_workers",
+		"Simulation worker goroutines currently running.")
+)
+
+// ActiveWorkers is the number of simulation workers running right now,
+// across every simulation in the process.
+func ActiveWorkers() int {
+	return int(activeWorkers.Value())
+}
+
+// observe records one finished simulation of the given kind: "equity",
+// "allin" or "draw".
+func observe(kind string, iterations int, elapsed time.Duration) {
+	simulationsTotal.With(kind).Inc()
+	iterationsTotal.With(kind).Add(float64(iterations))
+	simulationDuration.With(kind).Observe(elapsed.Seconds())
+	if elapsed > 0 {
+		iterationsPerSecond.With(kind).Set(float64(iterations) / elapsed.Seconds())
+	}
+}
@@ -1,7 +1,12 @@
 package simulator
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
 )
@@ -14,53 +19,231 @@ func NewSimulator(config Config) *Simulator {
 	return &Simulator{config: config}
 }
 
+var (
+	ErrDuplicateCard  = errors.New("card is used more than once")
+	ErrInvalidCard    = errors.New("invalid card")
+	ErrNotEnoughCards = errors.New("not enough cards left in the deck")
+	ErrCardCount      = errors.New("wrong number of cards")
+	ErrIterations     = errors.New("invalid number of iterations or workers")
+)
+
+// IsInvalid reports whether err means the simulation could not be run as
+// asked, as opposed to failing while it ran.
+func IsInvalid(err error) bool {
+	return errors.Is(err, ErrDuplicateCard) || errors.Is(err, ErrInvalidCard) ||
+		errors.Is(err, ErrNotEnoughCards) || errors.Is(err, ErrCardCount) ||
+		errors.Is(err, ErrIterations)
+}
+
+// checkIterations makes sure every one of the workers has at least one
+// iteration to run.
+func checkIterations(iterations int, concurrent int) error {
+	if iterations <= 0 {
+		return fmt.Errorf("%w: iterations must be positive", ErrIterations)
+	}
+	if concurrent <= 0 {
+		return fmt.Errorf("%w: workers must be positive", ErrIterations)
+	}
+	if iterations < concurrent {
+		return fmt.Errorf("%w: %d iterations cannot be split between %d workers", ErrIterations, iterations, concurrent)
+	}
+	return nil
+}
+
+func (s *Simulator) validate() error {
+	if err := checkIterations(s.config.NumIterations, s.config.NumConcurrent); err != nil {
+		return err
+	}
+	if len(s.config.PlayerHand.Cards) != 2 {
+		return fmt.Errorf("%w: exactly 2 player cards are required", ErrCardCount)
+	}
+	if len(s.config.OpponentHand.Cards) > 2 {
+		return fmt.Errorf("%w: at most 2 opponent cards are allowed", ErrCardCount)
+	}
+	if len(s.config.CommunityCards) > 5 {
+		return fmt.Errorf("%w: at most 5 community cards are allowed", ErrCardCount)
+	}
+
+	known, err := checkCards(
+		s.config.PlayerHand.Cards,
+		s.config.OpponentHand.Cards,
+		s.config.CommunityCards,
+		s.config.DeadCards,
+	)
+	if err != nil {
+		return err
+	}
+
+	needed := (5 - len(s.config.CommunityCards)) + (2 - len(s.config.OpponentHand.Cards))
+	if 52-known < needed {
+		return ErrNotEnoughCards
+	}
+
+	return nil
+}
+
+// CheckCards reports cards that do not exist or are used more than once
+// across the groups, with ErrInvalidCard or ErrDuplicateCard.
+func CheckCards(groups ...[]poker.Card) error {
+	_, err := checkCards(groups...)
+	return err
+}
+
+// checkCards is CheckCards that also returns how many cards the groups
+// hold.
+func checkCards(groups ...[]poker.Card) (int, error) {
+	seen := make(map[poker.Card]bool)
+
+	for _, cards := range groups {
+		for _, card := range cards {
+			if card.Rank < poker.Two || card.Rank > poker.Ace || card.Suit < poker.Clubs || card.Suit > poker.Spades {
+				return 0, fmt.Errorf("%w: %+v", ErrInvalidCard, card)
+			}
+			if seen[card] {
+				return 0, fmt.Errorf("%w: %v", ErrDuplicateCard, card)
+			}
+			seen[card] = true
+		}
+	}
+
+	return len(seen), nil
+}
+
+// Validate reports whether the configuration can be simulated, without
+// running it.
+func (s *Simulator) Validate() error {
+	return s.validate()
+}
+
 func (s *Simulator) RunSimulation() (*Result, error) {
-	results := make(chan poker.Result, s.config.NumIterations)
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
 
-	var wg sync.WaitGroup
+	start := time.Now()
+	result := runTally(context.Background(), s.config.NumIterations, s.config.NumConcurrent, s.runSingleSimulation, nil)
 
-	iterationsPerWorker := s.config.NumIterations / s.config.NumConcurrent
+	observe("equity", result.Iterations, time.Since(start))
+	slog.Debug("simulation finished",
+		"iterations", result.Iterations,
+		"workers", s.config.NumConcurrent,
+		"duration", time.Since(start),
+	)
 
-	for i := 0; i < s.config.NumConcurrent; i++ {
-		wg.Add(1)
-		go s.simulationWorker(iterationsPerWorker, results, &wg)
+	return result, nil
+}
+
+// progressInterval is how many iterations a worker runs between reporting
+// progress and checking for cancellation in RunSimulationContext.
+const progressInterval = 1_000
+
+// RunSimulationContext is RunSimulation for long runs. It calls progress
+// with the running totals as the workers go, and when ctx is done it stops
+// early and returns the result so far together with ctx.Err().
+func (s *Simulator) RunSimulationContext(ctx context.Context, progress func(*Result)) (*Result, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
 	}
 
-	go func() {
-		wg.Wait()
-		close(results)
-	}()
+	start := time.Now()
+	result := runTally(ctx, s.config.NumIterations, s.config.NumConcurrent, s.runSingleSimulation, progress)
 
-	wins, losses, ties := 0, 0, 0
+	observe("equity", result.Iterations, time.Since(start))
+	slog.DebugContext(ctx, "simulation finished",
+		"iterations", result.Iterations,
+		"workers", s.config.NumConcurrent,
+		"duration", time.Since(start),
+		"canceled", ctx.Err() != nil,
+	)
+
+	return result, ctx.Err()
+}
 
-	for result := range results {
-		switch result {
-		case poker.Win:
-			wins++
-		case poker.Lose:
-			losses++
-		case poker.Tie:
-			ties++
+// runTally splits iterations of simulate between concurrent workers and
+// adds up their results. Every progressInterval iterations a worker merges
+// its results into the totals, calls progress if it is not nil, and stops
+// if ctx is done.
+func runTally(ctx context.Context, iterations int, concurrent int, simulate func() poker.Result, progress func(*Result)) *Result {
+	var mu sync.Mutex
+	totals := tally{}
+
+	runWorkers(ctx, iterations, concurrent, func(n int) {
+		local := tally{}
+		for i := 0; i < n; i++ {
+			local.add(simulate())
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		totals.merge(local)
+		if progress != nil {
+			progress(totals.result())
 		}
+	})
+
+	return totals.result()
+}
+
+// runWorkers splits iterations between concurrent workers. Each worker runs
+// its share by calling batch with at most progressInterval iterations at a
+// time, and stops after a batch once ctx is done.
+func runWorkers(ctx context.Context, iterations int, concurrent int, batch func(n int)) {
+	var wg sync.WaitGroup
+
+	iterationsPerWorker := iterations / concurrent
+
+	for i := 0; i < concurrent; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			activeWorkers.Inc()
+			defer activeWorkers.Dec()
+
+			for done := 0; done < iterationsPerWorker; done += progressInterval {
+				batch(min(progressInterval, iterationsPerWorker-done))
+
+				if ctx.Err() != nil {
+					return
+				}
+			}
+		}()
 	}
 
-	totalIterations := float64(wins + losses + ties)
+	wg.Wait()
+}
 
-	return &Result{
-		WinProbability:  float64(wins) / totalIterations,
-		LoseProbability: float64(losses) / totalIterations,
-		TieProbability:  float64(ties) / totalIterations,
-		Iterations:      int(totalIterations),
-	}, nil
+type tally struct {
+	wins, losses, ties int
+}
+
+func (t *tally) add(result poker.Result) {
+	switch result {
+	case poker.Win:
+		t.wins++
+	case poker.Lose:
+		t.losses++
+	case poker.Tie:
+		t.ties++
+	}
+}
 
+func (t *tally) merge(other tally) {
+	t.wins += other.wins
+	t.losses += other.losses
+	t.ties += other.ties
 }
 
-func (s *Simulator) simulationWorker(iterations int, results chan<- poker.Result, wg *sync.WaitGroup) {
-	defer wg.Done()
+func (t *tally) result() *Result {
+	total := t.wins + t.losses + t.ties
+	if total == 0 {
+		return &Result{}
+	}
 
-	for i := 0; i < iterations; i += 1 {
-		result := s.runSingleSimulation()
-		results <- result
+	return &Result{
+		WinProbability:  float64(t.wins) / float64(total),
+		LoseProbability: float64(t.losses) / float64(total),
+		TieProbability:  float64(t.ties) / float64(total),
+		Iterations:      total,
 	}
 }
 
@@ -81,18 +264,22 @@ func (s *Simulator) runSingleSimulation() poker.Result {
 }
 
 func (s *Simulator) removeKnownCards(deck poker.Deck) poker.Deck {
-	knownCards := make(map[poker.Card]bool)
-
-	for _, card := range s.config.PlayerHand.Cards {
-		knownCards[card] = true
-	}
+	return removeCards(deck,
+		s.config.PlayerHand.Cards,
+		s.config.OpponentHand.Cards,
+		s.config.CommunityCards,
+		s.config.DeadCards,
+	)
+}
 
-	for _, card := range s.config.OpponentHand.Cards {
-		knownCards[card] = true
-	}
+// removeCards returns the deck without any of the cards in groups.
+func removeCards(deck poker.Deck, groups ...[]poker.Card) poker.Deck {
+	knownCards := make(map[poker.Card]bool)
 
-	for _, card := range s.config.CommunityCards {
-		knownCards[card] = true
+	for _, cards := range groups {
+		for _, card := range cards {
+			knownCards[card] = true
+		}
 	}
 
 	var remainingCards []poker.Card
@@ -104,5 +291,4 @@ func (s *Simulator) removeKnownCards(deck poker.Deck) poker.Deck {
 	}
 
 	return poker.Deck{Cards: remainingCards}
-
 }
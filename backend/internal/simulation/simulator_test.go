@@ -0,0 +1,374 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/poker"
+)
+
+func TestRunSimulationDeadCards(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr error
+	}{
+		{
+			name: "Dead card duplicates player card",
+			config: Config{
+				PlayerHand: poker.NewHand(poker.Card{Rank: poker.Ace, Suit: poker.Spades}, poker.Card{Rank: poker.King, Suit: poker.Spades}),
+				DeadCards:  []poker.Card{{Rank: poker.Ace, Suit: poker.Spades}},
+			},
+			wantErr: ErrDuplicateCard,
+		},
+		{
+			name: "Dead card listed twice",
+			config: Config{
+				PlayerHand: poker.NewHand(poker.Card{Rank: poker.Ace, Suit: poker.Spades}, poker.Card{Rank: poker.King, Suit: poker.Spades}),
+				DeadCards:  []poker.Card{{Rank: poker.Two, Suit: poker.Clubs}, {Rank: poker.Two, Suit: poker.Clubs}},
+			},
+			wantErr: ErrDuplicateCard,
+		},
+		{
+			name: "Invalid dead card",
+			config: Config{
+				PlayerHand: poker.NewHand(poker.Card{Rank: poker.Ace, Suit: poker.Spades}, poker.Card{Rank: poker.King, Suit: poker.Spades}),
+				DeadCards:  []poker.Card{{Rank: 1, Suit: poker.Clubs}},
+			},
+			wantErr: ErrInvalidCard,
+		},
+		{
+			name: "Opponent has three cards",
+			config: Config{
+				PlayerHand:   poker.NewHand(poker.Card{Rank: poker.Ace, Suit: poker.Spades}, poker.Card{Rank: poker.King, Suit: poker.Spades}),
+				OpponentHand: poker.NewHand(poker.Card{Rank: poker.Two, Suit: poker.Clubs}, poker.Card{Rank: poker.Three, Suit: poker.Clubs}, poker.Card{Rank: poker.Four, Suit: poker.Clubs}),
+			},
+			wantErr: ErrCardCount,
+		},
+		{
+			name: "Player has three cards",
+			config: Config{
+				PlayerHand: poker.NewHand(poker.Card{Rank: poker.Ace, Suit: poker.Spades}, poker.Card{Rank: poker.King, Suit: poker.Spades}, poker.Card{Rank: poker.Queen, Suit: poker.Spades}),
+			},
+			wantErr: ErrCardCount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.NumIterations = 100
+			tt.config.NumConcurrent = 1
+
+			if _, err := NewSimulator(tt.config).RunSimulation(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("RunSimulation() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunSimulationIterations(t *testing.T) {
+	tests := []struct {
+		name          string
+		numIterations int
+		numConcurrent int
+	}{
+		{"No workers", 100, 0},
+		{"Negative workers", 100, -1},
+		{"No iterations", 0, 1},
+		{"Fewer iterations than workers", 3, 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{
+				PlayerHand:    poker.NewHand(poker.Card{Rank: poker.Ace, Suit: poker.Spades}, poker.Card{Rank: poker.King, Suit: poker.Spades}),
+				NumIterations: tt.numIterations,
+				NumConcurrent: tt.numConcurrent,
+			}
+
+			_, err := NewSimulator(config).RunSimulation()
+			if !errors.Is(err, ErrIterations) || !IsInvalid(err) {
+				t.Errorf("RunSimulation() error = %v, want %v", err, ErrIterations)
+			}
+		})
+	}
+}
+
+func TestRunSimulationContextCancel(t *testing.T) {
+	config := Config{
+		PlayerHand:    poker.NewHand(poker.Card{Rank: poker.Ace, Suit: poker.Spades}, poker.Card{Rank: poker.King, Suit: poker.Spades}),
+		NumIterations: 10_000_000,
+		NumConcurrent: 2,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	reports := 0
+
+	result, err := NewSimulator(config).RunSimulationContext(ctx, func(partial *Result) {
+		reports++
+		if partial.Iterations >= 4*progressInterval {
+			cancel()
+		}
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("RunSimulationContext() error = %v, want %v", err, context.Canceled)
+	}
+	if result.Iterations < 4*progressInterval || result.Iterations >= config.NumIterations {
+		t.Errorf("expected a partial result, got %d iterations", result.Iterations)
+	}
+	if reports == 0 || result.WinProbability <= 0 {
+		t.Errorf("unexpected partial result %+v after %d reports", result, reports)
+	}
+}
+
+func TestRemoveKnownCardsExcludesDeadCards(t *testing.T) {
+	dead := poker.Card{Rank: poker.Two, Suit: poker.Clubs}
+	sim := NewSimulator(Config{
+		PlayerHand: poker.NewHand(poker.Card{Rank: poker.Ace, Suit: poker.Spades}, poker.Card{Rank: poker.King, Suit: poker.Spades}),
+		DeadCards:  []poker.Card{dead},
+	})
+
+	deck := sim.removeKnownCards(poker.NewDeck())
+	if len(deck.Cards) != 49 {
+		t.Fatalf("expected 49 cards, got %d", len(deck.Cards))
+	}
+
+	for _, card := range deck.Cards {
+		if card == dead {
+			t.Errorf("dead card %v left in deck", dead)
+		}
+	}
+}
+
+func TestAllInSimulationConservesChips(t *testing.T) {
+	sim := NewAllInSimulator(AllInConfig{
+		Hands: []poker.Hand{
+			poker.NewHand(poker.Card{Rank: poker.Ace, Suit: poker.Spades}, poker.Card{Rank: poker.Ace, Suit: poker.Hearts}),
+			poker.NewHand(poker.Card{Rank: poker.King, Suit: poker.Spades}, poker.Card{Rank: poker.King, Suit: poker.Hearts}),
+			poker.NewHand(poker.Card{Rank: poker.Seven, Suit: poker.Clubs}, poker.Card{Rank: poker.Two, Suit: poker.Diamonds}),
+		},
+		Contributions: []int{100, 300, 300},
+		NumIterations: 2000,
+		NumConcurrent: 4,
+	})
+
+	result, err := sim.RunSimulation()
+	if err != nil {
+		t.Fatalf("RunSimulation() error = %v", err)
+	}
+
+	total := 0.0
+	for _, chips := range result.ExpectedChips {
+		total += chips
+	}
+
+	if total < 699.99 || total > 700.01 {
+		t.Errorf("expected chips to sum to 700, got %v", total)
+	}
+
+	if result.ExpectedChips[0] > 300 {
+		t.Errorf("short stack can win at most the main pot, got %v", result.ExpectedChips[0])
+	}
+}
+
+func TestAllInSimulationExact(t *testing.T) {
+	sim := NewAllInSimulator(AllInConfig{
+		Hands: []poker.Hand{
+			poker.NewHand(poker.Card{Rank: poker.Ace, Suit: poker.Spades}, poker.Card{Rank: poker.Ace, Suit: poker.Hearts}),
+			poker.NewHand(poker.Card{Rank: poker.King, Suit: poker.Spades}, poker.Card{Rank: poker.King, Suit: poker.Hearts}),
+		},
+		Contributions:  []int{100, 100},
+		CommunityCards: []poker.Card{{Rank: poker.Two, Suit: poker.Clubs}, {Rank: poker.Seven, Suit: poker.Diamonds}, {Rank: poker.Nine, Suit: poker.Clubs}},
+		DeadCards:      []poker.Card{{Rank: poker.King, Suit: poker.Clubs}},
+		NumConcurrent:  3,
+		Exact:          true,
+	})
+
+	result, err := sim.RunSimulation()
+	if err != nil {
+		t.Fatalf("RunSimulation() error = %v", err)
+	}
+
+	// 44 unseen cards leave C(44, 2) turn and river combinations, of which
+	// the kings only win when the K♦ comes without one of the two live aces.
+	if result.Iterations != 946 {
+		t.Errorf("Iterations = %d, want 946", result.Iterations)
+	}
+	if got := result.ExpectedChips[1] * float64(result.Iterations); got != 41*200 {
+		t.Errorf("kings won %v chips, want %v", got, 41*200)
+	}
+}
+
+func TestAllInSimulationValidation(t *testing.T) {
+	aces := poker.NewHand(poker.Card{Rank: poker.Ace, Suit: poker.Spades}, poker.Card{Rank: poker.Ace, Suit: poker.Hearts})
+	kings := poker.NewHand(poker.Card{Rank: poker.King, Suit: poker.Spades}, poker.Card{Rank: poker.King, Suit: poker.Hearts})
+
+	tests := []struct {
+		name    string
+		config  AllInConfig
+		wantErr error
+	}{
+		{"One hand", AllInConfig{Hands: []poker.Hand{aces}, Contributions: []int{100}, NumIterations: 100, NumConcurrent: 1}, ErrCardCount},
+		{"Missing contribution", AllInConfig{Hands: []poker.Hand{aces, kings}, Contributions: []int{100}, NumIterations: 100, NumConcurrent: 1}, ErrCardCount},
+		{"Unknown hand in exact mode", AllInConfig{Hands: []poker.Hand{aces, poker.NewHand()}, Contributions: []int{100, 100}, NumConcurrent: 1, Exact: true}, ErrCardCount},
+		{"Invalid card", AllInConfig{Hands: []poker.Hand{aces, poker.NewHand(poker.Card{Rank: 15, Suit: poker.Spades})}, Contributions: []int{100, 100}, NumIterations: 100, NumConcurrent: 1}, ErrInvalidCard},
+		{"Duplicate card", AllInConfig{Hands: []poker.Hand{aces, aces}, Contributions: []int{100, 100}, NumIterations: 100, NumConcurrent: 1}, ErrDuplicateCard},
+		{"Fewer iterations than workers", AllInConfig{Hands: []poker.Hand{aces, kings}, Contributions: []int{100, 100}, NumIterations: 3, NumConcurrent: 8}, ErrIterations},
+		{"No workers in exact mode", AllInConfig{Hands: []poker.Hand{aces, kings}, Contributions: []int{100, 100}, Exact: true}, ErrIterations},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAllInSimulator(tt.config).RunSimulation()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RunSimulation() error = %v, want %v", err, tt.wantErr)
+			}
+			if !IsInvalid(err) {
+				t.Errorf("IsInvalid(%v) = false", err)
+			}
+		})
+	}
+}
+
+func TestDrawSimulationValidation(t *testing.T) {
+	sevenLow := poker.NewHand(
+		poker.Card{Rank: poker.Seven, Suit: poker.Spades},
+		poker.Card{Rank: poker.Five, Suit: poker.Hearts},
+		poker.Card{Rank: poker.Four, Suit: poker.Clubs},
+		poker.Card{Rank: poker.Three, Suit: poker.Diamonds},
+		poker.Card{Rank: poker.Two, Suit: poker.Spades},
+	)
+
+	tests := []struct {
+		name    string
+		config  DrawConfig
+		wantErr error
+	}{
+		{
+			name: "Opponent card duplicates player card",
+			config: DrawConfig{
+				PlayerHand:   sevenLow,
+				OpponentHand: poker.NewHand(poker.Card{Rank: poker.Seven, Suit: poker.Spades}),
+			},
+			wantErr: ErrDuplicateCard,
+		},
+		{
+			name: "Player card listed twice",
+			config: DrawConfig{
+				PlayerHand: poker.NewHand(append(sevenLow.Cards[:4:4], poker.Card{Rank: poker.Seven, Suit: poker.Spades})...),
+			},
+			wantErr: ErrDuplicateCard,
+		},
+		{
+			name: "Invalid opponent card",
+			config: DrawConfig{
+				PlayerHand:   sevenLow,
+				OpponentHand: poker.NewHand(poker.Card{Rank: 15, Suit: poker.Clubs}),
+			},
+			wantErr: ErrInvalidCard,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.NumDraws = SingleDraw
+			tt.config.NumIterations = 100
+			tt.config.NumConcurrent = 1
+
+			if _, err := NewDrawSimulator(tt.config).RunSimulation(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("RunSimulation() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := NewDrawSimulator(DrawConfig{PlayerHand: poker.NewHand(sevenLow.Cards[:4]...), NumIterations: 100, NumConcurrent: 1}).RunSimulation(); err == nil {
+		t.Errorf("RunSimulation() with 4 player cards returned no error")
+	}
+}
+
+func TestDrawSimulation(t *testing.T) {
+	sevenLow := poker.NewHand(
+		poker.Card{Rank: poker.Seven, Suit: poker.Spades},
+		poker.Card{Rank: poker.Five, Suit: poker.Hearts},
+		poker.Card{Rank: poker.Four, Suit: poker.Clubs},
+		poker.Card{Rank: poker.Three, Suit: poker.Diamonds},
+		poker.Card{Rank: poker.Two, Suit: poker.Spades},
+	)
+
+	tests := []struct {
+		name    string
+		config  DrawConfig
+		minimum float64
+	}{
+		{
+			name:    "Pat nuts against a random hand",
+			config:  DrawConfig{Game: poker.DeuceToSeven, PlayerHand: sevenLow},
+			minimum: 0.99,
+		},
+		{
+			name: "Pat nuts against a triple draw",
+			config: DrawConfig{
+				Game:             poker.DeuceToSeven,
+				PlayerHand:       sevenLow,
+				OpponentDiscards: 3,
+				NumDraws:         TripleDraw,
+			},
+			minimum: 0.95,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.NumIterations = 4_000
+			tt.config.NumConcurrent = 4
+
+			result, err := NewDrawSimulator(tt.config).RunSimulation()
+			if err != nil {
+				t.Fatalf("RunSimulation() error = %v", err)
+			}
+			if result.Iterations != 4_000 {
+				t.Errorf("Iterations = %d, want 4000", result.Iterations)
+			}
+			if result.WinProbability < tt.minimum {
+				t.Errorf("WinProbability = %.3f, want at least %.2f", result.WinProbability, tt.minimum)
+			}
+		})
+	}
+}
+
+func TestRequestConfig(t *testing.T) {
+	aces := []poker.Card{{Rank: poker.Ace, Suit: poker.Spades}, {Rank: poker.Ace, Suit: poker.Hearts}}
+	low := []poker.Card{{Rank: poker.Two, Suit: poker.Clubs}, {Rank: poker.Three, Suit: poker.Clubs}, {Rank: poker.Four, Suit: poker.Clubs}}
+
+	tests := []struct {
+		name           string
+		req            Request
+		wantIterations int
+		wantConcurrent int
+		wantErr        error
+	}{
+		{"Defaults", Request{PlayerCards: aces}, 5_000, 4, nil},
+		{"Within limits", Request{PlayerCards: aces, NumIterations: 300, NumConcurrent: 2}, 300, 2, nil},
+		{"Over limits", Request{PlayerCards: aces, NumIterations: 9_000, NumConcurrent: 9}, 5_000, 4, nil},
+		{"More workers than iterations", Request{PlayerCards: aces, NumIterations: 3, NumConcurrent: 4}, 3, 3, nil},
+		{"One player card", Request{PlayerCards: aces[:1]}, 0, 0, ErrCardCount},
+		{"Three opponent cards", Request{PlayerCards: aces, OpponentCards: low}, 0, 0, ErrCardCount},
+		{"Six community cards", Request{PlayerCards: aces, CommunityCards: append(low, low...)}, 0, 0, ErrCardCount},
+		{"Duplicate card", Request{PlayerCards: aces, DeadCards: aces[:1]}, 0, 0, ErrDuplicateCard},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := tt.req.Config(5_000, 4)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Config() error = %v, want %v", err, tt.wantErr)
+			}
+			if config.NumIterations != tt.wantIterations || config.NumConcurrent != tt.wantConcurrent {
+				t.Errorf("Config() = %d iterations on %d workers, want %d on %d",
+					config.NumIterations, config.NumConcurrent, tt.wantIterations, tt.wantConcurrent)
+			}
+		})
+	}
+}
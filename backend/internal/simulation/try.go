@@ -41,7 +41,8 @@ func TrySimulatorWithRanomCardsOnHand(iterations int, concurrent int) {
 	fmt.Println("════════════════════════════════════════════")
 	fmt.Printf("🎴 Your Hand:       %v\n", playerHand)
 	fmt.Printf("🎴 Opponent's Hand: %v\n", opponentHand)
-	fmt.Printf("🃏 Community Cards: %v\n\n", communityCards)
+	fmt.Printf("🃏 Community Cards: %v\n", communityCards)
+	fmt.Printf("💪 Current Hand:    %s\n\n", playerHand.EvaluateHandStrenght(communityCards).Describe())
 	fmt.Printf("📈 RESULTS (from %d simulations):\n", result.Iterations)
 	fmt.Printf("🏆 Win:  %.2f%%\n", result.WinProbability*100)
 	fmt.Printf("🤝 Tie:  %.2f%%\n", result.TieProbability*100)
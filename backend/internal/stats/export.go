@@ -0,0 +1,53 @@
+package stats
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+func WriteJSON(w io.Writer, stats []PlayerStats) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(stats)
+}
+
+// WriteCSV writes one row per player. Every percentage column is followed by
+// its sample size.
+func WriteCSV(w io.Writer, stats []PlayerStats) error {
+	out := csv.NewWriter(w)
+
+	header := []string{"player", "hands"}
+	for _, name := range []string{"vpip", "pfr", "three_bet", "fold_to_three_bet", "cbet"} {
+		header = append(header, name, name+"_opportunities")
+	}
+	header = append(header, "aggression_factor", "wtsd", "wtsd_opportunities", "wsd", "wsd_opportunities")
+
+	if err := out.Write(header); err != nil {
+		return err
+	}
+
+	for _, player := range stats {
+		row := []string{player.Player, strconv.Itoa(player.Hands)}
+		for _, ratio := range []Ratio{player.VPIP, player.PFR, player.ThreeBet, player.FoldToThreeBet, player.CBet} {
+			row = append(row, formatPercent(ratio.Percent), strconv.Itoa(ratio.Opportunities))
+		}
+		row = append(row,
+			strconv.FormatFloat(player.AggressionFactor, 'f', 2, 64),
+			formatPercent(player.WTSD.Percent), strconv.Itoa(player.WTSD.Opportunities),
+			formatPercent(player.WSD.Percent), strconv.Itoa(player.WSD.Opportunities),
+		)
+
+		if err := out.Write(row); err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+func formatPercent(percent float64) string {
+	return strconv.FormatFloat(percent, 'f', 1, 64)
+}
@@ -0,0 +1,210 @@
+package stats
+
+import (
+	"slices"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/game"
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/handhistory"
+)
+
+// Ratio is a percentage stat together with its sample size, so a 100% PFR
+// over three hands can be told apart from one over three thousand.
+type Ratio struct {
+	Count         int     `json:"count"`
+	Opportunities int     `json:"opportunities"`
+	Percent       float64 `json:"percent"`
+}
+
+func (r *Ratio) record(opportunity bool, success bool) {
+	if !opportunity {
+		return
+	}
+	r.Opportunities++
+	if success {
+		r.Count++
+	}
+	r.Percent = float64(r.Count) / float64(r.Opportunities) * 100
+}
+
+type PlayerStats struct {
+	Player            string  `json:"player"`
+	Hands             int     `json:"hands"`
+	VPIP              Ratio   `json:"vpip"`
+	PFR               Ratio   `json:"pfr"`
+	ThreeBet          Ratio   `json:"threeBet"`
+	FoldToThreeBet    Ratio   `json:"foldToThreeBet"`
+	CBet              Ratio   `json:"cBet"`
+	AggressionFactor  float64 `json:"aggressionFactor"`
+	AggressiveActions int     `json:"aggressiveActions"`
+	PassiveActions    int     `json:"passiveActions"`
+	WTSD              Ratio   `json:"wtsd"`
+	WSD               Ratio   `json:"wsd"`
+}
+
+// Compute aggregates the hands into one PlayerStats per player, sorted by
+// name.
+func Compute(hands []handhistory.Hand) []PlayerStats {
+	byPlayer := make(map[string]*PlayerStats)
+
+	for _, hand := range hands {
+		for _, player := range handPlayers(hand) {
+			stats, ok := byPlayer[player]
+			if !ok {
+				stats = &PlayerStats{Player: player}
+				byPlayer[player] = stats
+			}
+			stats.add(hand)
+		}
+	}
+
+	result := make([]PlayerStats, 0, len(byPlayer))
+	for _, stats := range byPlayer {
+		result = append(result, *stats)
+	}
+	slices.SortFunc(result, func(a, b PlayerStats) int {
+		if a.Player < b.Player {
+			return -1
+		} else if a.Player > b.Player {
+			return 1
+		}
+		return 0
+	})
+
+	return result
+}
+
+func ComputePlayer(hands []handhistory.Hand, player string) (PlayerStats, bool) {
+	stats := PlayerStats{Player: player}
+	for _, hand := range hands {
+		if slices.Contains(handPlayers(hand), player) {
+			stats.add(hand)
+		}
+	}
+	return stats, stats.Hands > 0
+}
+
+// handPlayers lists the players dealt into the hand: everyone who posted or
+// acted, which leaves out seats that were sitting out.
+func handPlayers(hand handhistory.Hand) []string {
+	var players []string
+	for _, seat := range hand.Seats {
+		for _, action := range hand.Actions {
+			if action.Player == seat.Player {
+				players = append(players, seat.Player)
+				break
+			}
+		}
+	}
+	return players
+}
+
+func (s *PlayerStats) add(hand handhistory.Hand) {
+	s.Hands++
+
+	preflop := hand.ActionsOn(game.Preflop)
+	player := s.Player
+
+	voluntary, raised := false, false
+	raisesBefore := 0
+	threeBetChance, threeBet := false, false
+	openRaiser, facedThreeBet, foldedToThreeBet := false, false, false
+	lastRaiser := ""
+
+	for _, action := range preflop {
+		if action.Player == player {
+			switch action.Type {
+			case handhistory.Call, handhistory.Bet:
+				voluntary = true
+			case handhistory.Raise:
+				voluntary, raised = true, true
+			}
+
+			if isDecision(action.Type) {
+				if raisesBefore == 1 && !openRaiser && !threeBetChance {
+					threeBetChance = true
+					threeBet = action.Type == handhistory.Raise
+				}
+				if openRaiser && raisesBefore == 2 && !facedThreeBet {
+					facedThreeBet = true
+					foldedToThreeBet = action.Type == handhistory.Fold
+				}
+			}
+		}
+
+		if action.Type == handhistory.Raise {
+			if raisesBefore == 0 && action.Player == player {
+				openRaiser = true
+			}
+			raisesBefore++
+			lastRaiser = action.Player
+		}
+	}
+
+	s.VPIP.record(true, voluntary)
+	s.PFR.record(true, raised)
+	s.ThreeBet.record(threeBetChance, threeBet)
+	s.FoldToThreeBet.record(facedThreeBet, foldedToThreeBet)
+
+	folded := hand.Folded()
+	sawFlop := len(hand.Board) >= 3 && !foldedOn(hand, player, game.Preflop)
+
+	if sawFlop && lastRaiser == player {
+		chance, bet := false, false
+		for _, action := range hand.ActionsOn(game.Flop) {
+			if action.Player == player && isDecision(action.Type) {
+				chance = true
+				bet = action.Type == handhistory.Bet
+				break
+			}
+			if action.Type == handhistory.Bet {
+				break
+			}
+		}
+		s.CBet.record(chance, bet)
+	}
+
+	for _, action := range hand.Actions {
+		if action.Player != player || action.Street == game.Preflop {
+			continue
+		}
+		switch action.Type {
+		case handhistory.Bet, handhistory.Raise:
+			s.AggressiveActions++
+		case handhistory.Call:
+			s.PassiveActions++
+		}
+	}
+	if s.PassiveActions > 0 {
+		s.AggressionFactor = float64(s.AggressiveActions) / float64(s.PassiveActions)
+	} else {
+		s.AggressionFactor = float64(s.AggressiveActions)
+	}
+
+	remaining := 0
+	for _, name := range handPlayers(hand) {
+		if !folded[name] {
+			remaining++
+		}
+	}
+	wentToShowdown := sawFlop && !folded[player] && remaining > 1
+
+	s.WTSD.record(sawFlop, wentToShowdown)
+	s.WSD.record(wentToShowdown, hand.Winnings[player] > 0)
+}
+
+func isDecision(actionType handhistory.ActionType) bool {
+	switch actionType {
+	case handhistory.Fold, handhistory.Check, handhistory.Call, handhistory.Bet, handhistory.Raise:
+		return true
+	}
+	return false
+}
+
+func foldedOn(hand handhistory.Hand, player string, street game.Street) bool {
+	for _, action := range hand.ActionsOn(street) {
+		if action.Player == player && action.Type == handhistory.Fold {
+			return true
+		}
+	}
+	return false
+}
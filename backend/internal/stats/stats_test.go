@@ -0,0 +1,90 @@
+package stats
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Palaszontko/texas-holdem-hand-calculator/backend/internal/handhistory"
+)
+
+func loadFixture(t *testing.T) []handhistory.Hand {
+	t.Helper()
+
+	file, err := os.Open("../handhistory/testdata/cash.txt")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer file.Close()
+
+	hands, err := handhistory.ParsePokerStars(file)
+	if err != nil {
+		t.Fatalf("ParsePokerStars() error = %v", err)
+	}
+	return hands
+}
+
+func TestComputePlayer(t *testing.T) {
+	hands := loadFixture(t)
+
+	tests := []struct {
+		player string
+		check  func(s PlayerStats) bool
+	}{
+		{"hero", func(s PlayerStats) bool {
+			return s.Hands == 2 && s.VPIP.Count == 2 && s.PFR.Count == 2 &&
+				s.ThreeBet == Ratio{1, 1, 100} && s.CBet.Opportunities == 0 &&
+				s.WTSD == Ratio{1, 1, 100} && s.WSD == Ratio{0, 1, 0} &&
+				s.AggressiveActions == 1 && s.PassiveActions == 1
+		}},
+		{"Villain One", func(s PlayerStats) bool {
+			return s.VPIP == Ratio{1, 2, 50} && s.PFR.Count == 1 &&
+				s.ThreeBet == Ratio{0, 1, 0} && s.FoldToThreeBet == Ratio{0, 1, 0} &&
+				s.AggressionFactor == 1 && s.WTSD == Ratio{0, 1, 0}
+		}},
+		{"fish: 77", func(s PlayerStats) bool {
+			return s.VPIP == Ratio{1, 2, 50} && s.PFR.Count == 0 &&
+				s.ThreeBet == Ratio{0, 1, 0} && s.WSD == Ratio{1, 1, 100}
+		}},
+		{"nit42", func(s PlayerStats) bool {
+			return s.VPIP == Ratio{0, 2, 0} && s.WTSD.Opportunities == 0
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.player, func(t *testing.T) {
+			stats, ok := ComputePlayer(hands, tt.player)
+			if !ok || !tt.check(stats) {
+				t.Errorf("unexpected stats: %+v", stats)
+			}
+		})
+	}
+
+	if _, ok := ComputePlayer(hands, "sleepy"); ok {
+		t.Error("players sitting out should have no stats")
+	}
+}
+
+func TestCompute(t *testing.T) {
+	all := Compute(loadFixture(t))
+
+	if len(all) != 4 || all[0].Player != "Villain One" || all[3].Player != "nit42" {
+		t.Errorf("unexpected players: %+v", all)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, Compute(loadFixture(t))); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 5 || !strings.HasPrefix(lines[0], "player,hands,vpip,vpip_opportunities") {
+		t.Errorf("unexpected csv:\n%s", buf.String())
+	}
+	if !strings.HasPrefix(lines[2], "fish: 77,2,50.0,2") {
+		t.Errorf("unexpected row: %s", lines[2])
+	}
+}
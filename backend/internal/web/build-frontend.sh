@@ -0,0 +1,13 @@
+#!/bin/sh
+# Builds the frontend to call the API on its own origin and copies it into
+# dist, where "go build -tags embedfrontend" embeds it.
+set -e
+
+cd "$(dirname "$0")"
+frontend=../../../frontend
+
+(cd "$frontend" && npm install && VITE_API_URL= npm run build)
+
+find dist -mindepth 1 ! -name .gitkeep -exec rm -rf {} +
+cp -R "$frontend/build/client/." dist/
+cp -R "$frontend/build/prerendered/." dist/
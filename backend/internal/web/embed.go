@@ -0,0 +1,21 @@
+//go:build embedfrontend
+
+package web
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed all:dist
+var dist embed.FS
+
+// Embedded returns the frontend built into the binary, or nil if dist held
+// no build when the binary was compiled.
+func Embedded() fs.FS {
+	assets, err := fs.Sub(dist, "dist")
+	if err != nil || Check(assets) != nil {
+		return nil
+	}
+	return assets
+}
@@ -0,0 +1,11 @@
+//go:build !embedfrontend
+
+package web
+
+import "io/fs"
+
+// Embedded returns nil: the frontend is only embedded in binaries built with
+// the embedfrontend tag.
+func Embedded() fs.FS {
+	return nil
+}
@@ -0,0 +1,167 @@
+// Package web serves the built frontend, either embedded in the binary or
+// from a directory, so that one server provides the whole app on one
+// origin.
+package web
+
+//go:generate sh build-frontend.sh
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrNoIndex = errors.New("frontend has no index.html")
+
+// Check reports whether assets look like a built frontend.
+func Check(assets fs.FS) error {
+	if _, err := fs.Stat(assets, "index.html"); err != nil {
+		return fmt.Errorf("%w: %v", ErrNoIndex, err)
+	}
+	return nil
+}
+
+// Cache-Control values. Files under _app/immutable have a content hash in
+// their name, so they never change; pages must be checked every time so
+// that a new build is picked up, and everything else, such as the card
+// images, may be kept for an hour.
+const (
+	cacheImmutable = "public, max-age=31536000, immutable"
+	cachePage      = "no-cache"
+	cacheAsset     = "public, max-age=3600"
+)
+
+// Handler serves the files in assets. Paths that match no file and have no
+// extension get index.html, so that the frontend's own routes survive a
+// reload. Missing API paths and missing files with an extension get 404.
+func Handler(assets fs.FS) http.Handler {
+	return &handler{assets: assets, etags: make(map[etagKey]string)}
+}
+
+type handler struct {
+	assets fs.FS
+
+	mu    sync.Mutex
+	etags map[etagKey]string
+}
+
+type etagKey struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	if strings.HasPrefix(name, "api/") || name == "api" {
+		http.NotFound(w, r)
+		return
+	}
+
+	for _, candidate := range candidates(name) {
+		if h.serve(w, r, candidate) {
+			return
+		}
+	}
+
+	if path.Ext(name) == "" && h.serve(w, r, "index.html") {
+		return
+	}
+	http.NotFound(w, r)
+}
+
+// candidates lists the files a path may be served from: the file itself,
+// or a prerendered page, which is written as name.html or
+// name/index.html.
+func candidates(name string) []string {
+	if name == "" {
+		return []string{"index.html"}
+	}
+	if path.Ext(name) != "" {
+		return []string{name}
+	}
+	return []string{name, name + ".html", path.Join(name, "index.html")}
+}
+
+// serve writes the named file and reports whether there was one.
+func (h *handler) serve(w http.ResponseWriter, r *http.Request, name string) bool {
+	file, err := h.assets.Open(name)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil || info.IsDir() {
+		return false
+	}
+	content, ok := file.(io.ReadSeeker)
+	if !ok {
+		return false
+	}
+
+	etag, err := h.etag(name, info, content)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return true
+	}
+
+	w.Header().Set("Cache-Control", cacheControl(name))
+	w.Header().Set("ETag", etag)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, name, info.ModTime(), content)
+	return true
+}
+
+func cacheControl(name string) string {
+	switch {
+	case strings.HasPrefix(name, "_app/immutable/"):
+		return cacheImmutable
+	case path.Ext(name) == ".html":
+		return cachePage
+	default:
+		return cacheAsset
+	}
+}
+
+// etag returns a hash of the file's content. Hashes are kept until the
+// file's size or modification time changes, which for embedded files,
+// whose modification time is zero, is never.
+func (h *handler) etag(name string, info fs.FileInfo, content io.ReadSeeker) (string, error) {
+	key := etagKey{name: name, size: info.Size(), modTime: info.ModTime()}
+
+	h.mu.Lock()
+	etag, ok := h.etags[key]
+	h.mu.Unlock()
+	if ok {
+		return etag, nil
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	etag = `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+
+	h.mu.Lock()
+	h.etags[key] = etag
+	h.mu.Unlock()
+	return etag, nil
+}
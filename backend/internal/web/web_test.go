@@ -0,0 +1,106 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+var assets = fstest.MapFS{
+	"index.html":                  {Data: []byte("<html>app</html>")},
+	"about.html":                  {Data: []byte("<html>about</html>")},
+	"favicon.png":                 {Data: []byte("png")},
+	"cards/AS.svg":                {Data: []byte("<svg>AS</svg>")},
+	"_app/immutable/entry/app.js": {Data: []byte("console.log(1)")},
+	"_app/immutable/assets/0.css": {Data: []byte("body{}")},
+	"_app/version.json":           {Data: []byte(`{"version":"1"}`)},
+}
+
+func TestHandler(t *testing.T) {
+	handler := Handler(assets)
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		status       int
+		body         string
+		cacheControl string
+		contentType  string
+	}{
+		{"Root", http.MethodGet, "/", http.StatusOK, "<html>app</html>", cachePage, "text/html; charset=utf-8"},
+		{"Index", http.MethodGet, "/index.html", http.StatusOK, "<html>app</html>", cachePage, "text/html; charset=utf-8"},
+		{"Prerendered page", http.MethodGet, "/about", http.StatusOK, "<html>about</html>", cachePage, "text/html; charset=utf-8"},
+		{"Immutable asset", http.MethodGet, "/_app/immutable/entry/app.js", http.StatusOK, "console.log(1)", cacheImmutable, "text/javascript; charset=utf-8"},
+		{"Static asset", http.MethodGet, "/cards/AS.svg", http.StatusOK, "<svg>AS</svg>", cacheAsset, "image/svg+xml"},
+		{"Mutable app file", http.MethodGet, "/_app/version.json", http.StatusOK, `{"version":"1"}`, cacheAsset, "application/json"},
+		{"Client route", http.MethodGet, "/hands/42", http.StatusOK, "<html>app</html>", cachePage, "text/html; charset=utf-8"},
+		{"Directory", http.MethodGet, "/cards", http.StatusOK, "<html>app</html>", cachePage, "text/html; charset=utf-8"},
+		{"Missing file", http.MethodGet, "/cards/ZZ.svg", http.StatusNotFound, "", "", ""},
+		{"Missing API path", http.MethodGet, "/api/unknown", http.StatusNotFound, "", "", ""},
+		{"Dot segments", http.MethodGet, "/../cards/../cards/AS.svg", http.StatusOK, "<svg>AS</svg>", cacheAsset, "image/svg+xml"},
+		{"Head", http.MethodHead, "/", http.StatusOK, "", cachePage, "text/html; charset=utf-8"},
+		{"Post", http.MethodPost, "/", http.StatusMethodNotAllowed, "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d", w.Code, tt.status)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			if w.Body.String() != tt.body {
+				t.Errorf("body = %q, want %q", w.Body.String(), tt.body)
+			}
+			if got := w.Header().Get("Cache-Control"); got != tt.cacheControl {
+				t.Errorf("Cache-Control = %q, want %q", got, tt.cacheControl)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.contentType)
+			}
+			if w.Header().Get("ETag") == "" {
+				t.Errorf("missing ETag")
+			}
+		})
+	}
+}
+
+func TestHandlerRevalidation(t *testing.T) {
+	handler := Handler(assets)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	etag := w.Header().Get("ETag")
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusNotModified {
+		t.Errorf("status with matching ETag = %d, want %d", w.Code, http.StatusNotModified)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/about", nil))
+	if w.Header().Get("ETag") == etag {
+		t.Errorf("different pages share the ETag %s", etag)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	if err := Check(assets); err != nil {
+		t.Errorf("Check() error = %v", err)
+	}
+	if err := Check(fstest.MapFS{"app.js": {}}); !errors.Is(err, ErrNoIndex) {
+		t.Errorf("Check() without index.html error = %v, want %v", err, ErrNoIndex)
+	}
+}
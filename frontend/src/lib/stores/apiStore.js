@@ -1,5 +1,9 @@
 import { writable } from 'svelte/store';
 
+// The API the app talks to. Builds embedded in the Go server set
+// VITE_API_URL to an empty string so that requests go to their own origin.
+const apiBase = import.meta.env.VITE_API_URL ?? 'https://texas-holdem-hand-calculator-api.onrender.com';
+
 export const probabilityResults = writable({
   winProbability: 0,
   loseProbability: 0,
@@ -47,7 +51,7 @@ export const calculateProbabilities = async (playerCards, opponentCards, communi
     numConcurrent: 8,
   };
 
-  const apiURL = 'https://texas-holdem-hand-calculator-api.onrender.com/api/simulation';
+  const apiURL = `${apiBase}/api/simulation`;
 
   try {
     const response = await fetch(apiURL, {
@@ -0,0 +1,3 @@
+// The app has a single page with no server data, so it is rendered at build
+// time. That gives the Go server a static index.html to embed.
+export const prerender = true;